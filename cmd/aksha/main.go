@@ -2,30 +2,65 @@ package main
 
 import (
 	"log"
+	"math/rand"
 	"net/url"
 	"time"
 
 	"github.com/gorilla/websocket"
-	"github.com/prfc0/aksha/internal/action"
+	"github.com/prfc0/aksha/internal/bot"
 	"github.com/prfc0/aksha/internal/card"
 	"github.com/prfc0/aksha/internal/game"
 	"github.com/prfc0/aksha/internal/player"
 	"github.com/prfc0/aksha/internal/table"
 )
 
+// spectator is the viewer ID of the web UI, which may only see shown cards.
+const spectator = ""
+
 type GameState struct {
-	// Players        []PlayerState `json:"players"`
-	Players        []*player.Player `json:"players"`
-	CommunityCards []*card.Card     `json:"communityCards"`
-	Pot            int              `json:"pot"`
+	Game           string         `json:"game"`
+	NextGame       string         `json:"nextGame,omitempty"`  // Game that comes next in a mixed-game rotation
+	HandsLeft      int            `json:"handsLeft,omitempty"` // Hands left of the current game in a mixed-game rotation
+	Players        []PlayerState  `json:"players"`
+	CommunityCards []*card.Card   `json:"communityCards"`
+	Runouts        [][]*card.Card `json:"runouts"` // Every board when the hand is run out more than once
+	Pot            int            `json:"pot"`
+}
+
+type PlayerState struct {
+	Name      string        `json:"name"`
+	Stack     int           `json:"stack"`
+	Bet       int           `json:"bet"`       // Chips committed on the current betting round
+	Committed int           `json:"committed"` // Chips committed over the whole hand
+	Status    player.Status `json:"status"`
+	Hand      []*card.Card  `json:"hand"` // Hole cards the viewer may see; hidden cards are nil
 }
 
 func sendGameState(conn *websocket.Conn, game *game.Game) {
 	gameState := GameState{
-		Players:        game.Players,
+		Game:           game.Variant.String(),
+		Players:        make([]PlayerState, 0, len(game.Players)),
 		CommunityCards: game.CommunityCards,
 		Pot:            game.Pot.Chips,
 	}
+	if game.Rotation != nil {
+		gameState.Game = game.Rotation.Game().String()
+		gameState.NextGame = game.Rotation.Next().String()
+		gameState.HandsLeft = game.Rotation.HandsRemaining()
+	}
+	for _, player := range game.Players {
+		gameState.Players = append(gameState.Players, PlayerState{
+			Name:      player.Name,
+			Stack:     player.Stack,
+			Bet:       player.Bet,
+			Committed: player.Committed,
+			Status:    player.Status(),
+			Hand:      game.VisibleHand(spectator, player),
+		})
+	}
+	for _, runout := range game.Runouts {
+		gameState.Runouts = append(gameState.Runouts, runout.Board)
+	}
 
 	// Broadcast game state
 	err := conn.WriteJSON(gameState)
@@ -64,111 +99,79 @@ func main() {
 		table.AddPlayer(player)
 	}
 	log.Println("FINISH: Adding players to table.")
+
+	// Choose who plays which strategy
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	strategies := map[string]bot.Strategy{
+		"1": bot.NewTightAggressive(rng),
+		"2": bot.NewTightAggressive(rng),
+		"3": bot.NewRandom(rng),
+		"4": bot.AlwaysCall{},
+		"5": bot.AlwaysCall{},
+	}
 	log.Println("--------------------------------")
 
 	// Initialize the game
 	log.Println("START: Initializing first trial game.")
-	game := game.NewGame(players, 1, 2)
+	game := game.NewGame(table.Players, 1, 2)
+	game.Table = table
+	game.StrictChips = true
 	log.Println("FINISH: Initializing first trial game.")
 	log.Println("--------------------------------")
 
 	// Start a new hand
-	log.Println("START: Initialization.")
-	game.StartHand()
-	log.Println("FINISH: Initialization.")
-	sendGameState(conn, game)
-	log.Println("--------------------------------")
-
-	log.Println("START: Post small and big blind.")
-	// Post small and big blinds
-	game.PostBlinds()
-	log.Println("FINISH: Post small and big blind.")
-	sendGameState(conn, game)
-	log.Println("--------------------------------")
-
-	// Deal cards to players
-	log.Println("START: Deal cards.")
-	game.DealCards()
+	log.Println("START: Post blinds and deal cards.")
+	if err := game.StartHand(); err != nil {
+		log.Fatal(err)
+	}
+	log.Println("FINISH: Post blinds and deal cards.")
 	sendGameState(conn, game)
-	log.Println("FINISH: Deal cards.")
 	log.Println("--------------------------------")
 
-	// Pre-flop: Everyone calls the big blind
-	activePlayers := table.ActivePlayers()
-	activePlayers = append(activePlayers[2:], activePlayers[0:2]...)
+	// Pre-flop: each player's strategy decides their actions
 	log.Println("START: Pre-flop betting round:")
-	for _, player := range activePlayers {
-		playerBet := player.Bet
-		currentBet := game.CurrentBet
-		toBet := currentBet - playerBet
-		if toBet > 0 {
-			actionObj := action.NewAction(action.Call, toBet)
-			err := player.PerformAction(actionObj, game.CurrentBet)
-			if err != nil {
-				log.Printf("Player %s could not perform action: %v\n", player.Name, err)
-			}
-			log.Printf("Player %s calls %d chips.\n", player.Name, toBet)
-			game.Pot.Chips += toBet
-		} else {
-			log.Printf("Player %s checks.\n", player.Name)
-		}
-	}
+	bot.PlayBettingRound(game, strategies)
 	log.Printf("Pot: %d\n", game.Pot.Chips)
 	log.Println("FINISH: Pre-flop betting round:")
 	sendGameState(conn, game)
 	log.Println("--------------------------------")
 
-	// Flop: Deal 3 community cards and everyone checks
+	// Flop: Deal 3 community cards and bet
 	log.Println("START: Flop betting round:")
 	game.DealCommunityCards(3)
-	game.PerformBettingRound()
+	bot.PlayBettingRound(game, strategies)
 	log.Printf("Pot: %d\n", game.Pot.Chips)
 	log.Println("FINISH: Flop betting round:")
 	sendGameState(conn, game)
 	log.Println("--------------------------------")
 
-	// Turn: Deal 1 community card and everyone checks
+	// Turn: Deal 1 community card and bet
 	log.Println("START: Turn betting round:")
 	game.DealCommunityCards(1)
-	game.PerformBettingRound()
+	bot.PlayBettingRound(game, strategies)
 	log.Printf("Pot: %d\n", game.Pot.Chips)
 	log.Println("FINISH: Turn betting round:")
 	sendGameState(conn, game)
 	log.Println("--------------------------------")
 
-	// River: Deal 1 community card and everyone checks
+	// River: Deal 1 community card and bet
 	log.Println("START: River betting round:")
 	game.DealCommunityCards(1)
-	game.PerformBettingRound()
+	bot.PlayBettingRound(game, strategies)
 	log.Printf("Pot: %d\n", game.Pot.Chips)
 	log.Println("FINISH: River betting round:")
 	sendGameState(conn, game)
 	log.Println("--------------------------------")
 
-	// Reveal everyone's cards
-	log.Println("START: Revealing hands:")
-	for _, player := range table.ActivePlayers() {
-		log.Printf("Player %s has: %v\n", player.Name, player.Hand)
-	}
-	log.Println("FINISH: Revealing hands:")
-	sendGameState(conn, game)
+	// Showdown: hands are revealed in order, beaten hands are mucked and
+	// the pots go to the winners; with StrictChips set a chip count
+	// mismatch panics
+	log.Println("START: Showdown:")
+	game.Muck = func(player *player.Player) bool { return true }
+	game.EndHand()
+	log.Println("FINISH: Showdown:")
 	log.Println("--------------------------------")
 
-	// Determine the winner
-	log.Println("START: Determining Winners:")
-	winners := game.DetermineWinner()
-	if len(winners) > 0 {
-		log.Printf("Player %s wins the pot of %d chips!\n", winners[0].Name, game.Pot.Chips)
-	} else {
-		log.Println("No winners.")
-	}
-	log.Println("FINISH: Determining Winners:")
-	sendGameState(conn, game)
-	log.Println("--------------------------------")
-
-	// Distribute the pot to the winner(s)
-	game.Pot.Distribute(winners)
-
 	// Display everyone's stack
 	log.Println("Final stacks:")
 	for _, player := range table.Players {
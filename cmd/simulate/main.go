@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/prfc0/aksha/internal/bot"
+	"github.com/prfc0/aksha/internal/sim"
+)
+
+var samples = flag.Int("samples", bot.DefaultSamples, "deals the tag bot samples per decision to estimate hand strength")
+
+// strategies creates the built-in bots by name.
+var strategies = map[string]func(rng *rand.Rand) bot.Strategy{
+	"call":   func(rng *rand.Rand) bot.Strategy { return bot.AlwaysCall{} },
+	"random": func(rng *rand.Rand) bot.Strategy { return bot.NewRandom(rng) },
+	"tag": func(rng *rand.Rand) bot.Strategy {
+		tag := bot.NewTightAggressive(rng)
+		tag.Samples = *samples
+		return tag
+	},
+}
+
+func main() {
+	bots := flag.String("bots", "tag,tag,random,call", "comma-separated strategies, one per seat: call, random or tag")
+	hands := flag.Int("hands", 10000, "hands to play in all")
+	tables := flag.Int("tables", runtime.NumCPU(), "tables to play at the same time; results depend only on the seed")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "seed of the shuffles and strategies")
+	smallBlind := flag.Int("sb", 1, "small blind")
+	bigBlind := flag.Int("bb", 2, "big blind")
+	stack := flag.Int("stack", 200, "chips every player starts each hand with")
+	verbose := flag.Bool("v", false, "log every hand")
+	flag.Parse()
+
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	config := sim.Config{
+		Hands:      *hands,
+		Tables:     *tables,
+		Seed:       *seed,
+		SmallBlind: *smallBlind,
+		BigBlind:   *bigBlind,
+		Stack:      *stack,
+	}
+	for i, name := range strings.Split(*bots, ",") {
+		strategy, ok := strategies[name]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Unknown strategy %q.\n", name)
+			os.Exit(2)
+		}
+		config.Entrants = append(config.Entrants, sim.Entrant{Name: fmt.Sprintf("%d:%s", i+1, name), Strategy: strategy})
+	}
+
+	start := time.Now()
+	results, err := sim.Run(config)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Played %d hands at %d tables in %s with seed %d.\n", config.Hands, config.Tables, time.Since(start).Round(time.Millisecond), config.Seed)
+	fmt.Printf("%-12s %8s %10s %10s\n", "Player", "Hands", "bb/100", "95% CI")
+	for _, result := range results {
+		fmt.Printf("%-12s %8d %+10.2f %10.2f\n", result.Name, result.Hands, result.BBPer100, result.CI95)
+	}
+}
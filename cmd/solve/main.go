@@ -0,0 +1,25 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/prfc0/aksha/internal/cfr"
+)
+
+func main() {
+	iterations := flag.Int("iterations", 10000, "iterations to train")
+	every := flag.Int("every", 1000, "iterations between exploitability reports")
+	plus := flag.Bool("plus", true, "train with CFR+ rather than vanilla CFR")
+	flag.Parse()
+
+	solver := cfr.NewSolver(*plus)
+	solver.Train(*iterations, *every, func(iteration int, exploitability float64) {
+		fmt.Printf("Iteration %d: exploitability %.6f chips per hand\n", iteration, exploitability)
+	})
+
+	profile := solver.Profile()
+	fmt.Printf("\nStrategy after %d iterations, by card and betting (p: pass, b: bet):\n%s", solver.Iterations, profile)
+	fmt.Printf("\nFirst player's value: %.6f (equilibrium -1/18 = %.6f)\n", profile.Value(), -1.0/18)
+	fmt.Printf("Exploitability: %.6f chips per hand\n", profile.Exploitability())
+}
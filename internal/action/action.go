@@ -3,6 +3,8 @@ package action
 import (
 	"fmt"
 	"log"
+
+	"github.com/prfc0/aksha/internal/card"
 )
 
 type ActionType string
@@ -12,11 +14,13 @@ const (
 	Call  ActionType = "Call"
 	Raise ActionType = "Raise"
 	Fold  ActionType = "Fold"
+	Draw  ActionType = "Draw" // Discard cards and draw replacements in a draw game
 )
 
 type Action struct {
 	Type   ActionType
 	Amount int
+	Cards  []*card.Card // Cards discarded by a Draw; none stands pat
 }
 
 func NewAction(actionType ActionType, amount int) *Action {
@@ -26,6 +30,14 @@ func NewAction(actionType ActionType, amount int) *Action {
 	}
 }
 
+// NewDraw creates a Draw action discarding the given cards.
+func NewDraw(discards []*card.Card) *Action {
+	return &Action{
+		Type:  Draw,
+		Cards: discards,
+	}
+}
+
 func (a *Action) Validate(playerStack, currentBet int) error {
 	switch a.Type {
 	case Bet, Raise:
@@ -36,7 +48,7 @@ func (a *Action) Validate(playerStack, currentBet int) error {
 		if a.Amount > playerStack {
 			return fmt.Errorf("player does not have enough chips to call %d", a.Amount)
 		}
-	case Fold:
+	case Fold, Draw:
 	default:
 		return fmt.Errorf("invalid action type: %s", a.Type)
 	}
@@ -55,7 +67,7 @@ func (a *Action) Execute(playerStack, currentBet int) (newStack, newPot int, err
 	case Call:
 		newStack = playerStack - a.Amount
 		newPot = currentBet + a.Amount
-	case Fold:
+	case Fold, Draw:
 		newStack = playerStack
 		newPot = currentBet
 	}
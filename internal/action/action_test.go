@@ -18,6 +18,7 @@ func TestActionValidation(t *testing.T) {
 		{Raise, 100, 200, true},
 		{Raise, 300, 200, false},
 		{Fold, 0, 200, true},
+		{Draw, 0, 200, true},
 	}
 
 	for _, test := range tests {
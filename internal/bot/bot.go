@@ -0,0 +1,137 @@
+package bot
+
+import (
+	"log"
+	"math/rand"
+
+	"github.com/prfc0/aksha/internal/action"
+	"github.com/prfc0/aksha/internal/card"
+	"github.com/prfc0/aksha/internal/game"
+	"github.com/prfc0/aksha/internal/player"
+)
+
+// Strategy decides a player's actions. Act is given the hand as the player
+// sees it and the actions they may take, and returns one of them.
+type Strategy interface {
+	Act(view *game.View, choices []game.Choice) *action.Action
+}
+
+// AlwaysCall checks or calls every bet and never draws.
+type AlwaysCall struct{}
+
+func (AlwaysCall) Act(view *game.View, choices []game.Choice) *action.Action {
+	if choice, ok := find(choices, action.Call); ok {
+		return action.NewAction(action.Call, choice.Max)
+	}
+	return action.NewDraw(nil)
+}
+
+// Random takes a random legal action, putting in a random amount of chips
+// when it bets or raises and discarding a random set of cards when it draws.
+type Random struct {
+	rng *rand.Rand
+}
+
+// NewRandom creates a Random strategy drawing from rng.
+func NewRandom(rng *rand.Rand) *Random {
+	return &Random{rng: rng}
+}
+
+func (b *Random) Act(view *game.View, choices []game.Choice) *action.Action {
+	choice := choices[b.rng.Intn(len(choices))]
+	if choice.Type == action.Draw {
+		discards := make([]*card.Card, 0)
+		for _, holeCard := range view.Hand {
+			if b.rng.Intn(2) == 0 {
+				discards = append(discards, holeCard)
+			}
+		}
+		return action.NewDraw(discards)
+	}
+	return action.NewAction(choice.Type, choice.Min+b.rng.Intn(choice.Max-choice.Min+1))
+}
+
+// find returns the choice of the given type, if it is among choices.
+func find(choices []game.Choice, actionType action.ActionType) (game.Choice, bool) {
+	for _, choice := range choices {
+		if choice.Type == actionType {
+			return choice, true
+		}
+	}
+	return game.Choice{}, false
+}
+
+// PlayBettingRound asks the players' strategies, by player ID, for their
+// actions until the current betting round is over. In a draw every player
+// draws before the betting starts. A player without a strategy, or whose
+// action the game rejects, folds.
+func PlayBettingRound(g *game.Game, strategies map[string]Strategy) {
+	for _, p := range g.ActionOrder() {
+		if choices := g.LegalActions(p); len(choices) == 1 && choices[0].Type == action.Draw {
+			act(g, p, strategies[p.ID], choices)
+		}
+	}
+
+	acted := make(map[string]bool)
+	for {
+		progressed := false
+		for _, p := range g.ActionOrder() {
+			if stillIn(g) < 2 {
+				return
+			}
+			if acted[p.ID] && p.Bet == g.CurrentBet {
+				continue
+			}
+			act(g, p, strategies[p.ID], g.LegalActions(p))
+			acted[p.ID] = true
+			progressed = true
+		}
+		if !progressed {
+			return
+		}
+	}
+}
+
+// PlayHand plays a whole hand of g with the players' strategies, by player
+// ID, dealing each street while more than one player is left in and then
+// ending the hand. It returns an error if the hand cannot be started.
+func PlayHand(g *game.Game, strategies map[string]Strategy) error {
+	if err := g.StartHand(); err != nil {
+		return err
+	}
+	PlayBettingRound(g, strategies)
+	for street := g.BettingRound + 1; street < len(g.Variant.Streets()) && stillIn(g) > 1; street++ {
+		g.DealStreet()
+		PlayBettingRound(g, strategies)
+	}
+	g.EndHand()
+	return nil
+}
+
+// act performs the action p's strategy chooses from choices.
+func act(g *game.Game, p *player.Player, strategy Strategy, choices []game.Choice) {
+	if len(choices) == 0 {
+		return
+	}
+	if strategy == nil {
+		log.Printf("Player %s has no strategy and folds.\n", p.Name)
+		p.Fold()
+		return
+	}
+	a := strategy.Act(g.View(p), choices)
+	if err := g.PerformAction(p, a); err != nil {
+		log.Printf("Player %s could not perform action: %v\n", p.Name, err)
+		p.Fold()
+	}
+}
+
+// stillIn returns the number of players still in the hand.
+func stillIn(g *game.Game) int {
+	count := 0
+	for _, player := range g.Players {
+		if player.Active {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,195 @@
+package bot
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/prfc0/aksha/internal/action"
+	"github.com/prfc0/aksha/internal/card"
+	"github.com/prfc0/aksha/internal/game"
+	"github.com/prfc0/aksha/internal/player"
+)
+
+func newPlayers(count int) []*player.Player {
+	names := []string{"Alice", "Bob", "Charlie", "Dave", "Eve", "Frank"}
+	players := make([]*player.Player, count)
+	for i := range players {
+		players[i] = player.NewPlayer(string(rune('1'+i)), names[i], 1000)
+	}
+	return players
+}
+
+func TestAlwaysCall(t *testing.T) {
+	tests := []struct {
+		name     string
+		choices  []game.Choice
+		expected action.Action
+	}{
+		{"facing a bet", []game.Choice{{Type: action.Fold}, {Type: action.Call, Min: 10, Max: 10}}, action.Action{Type: action.Call, Amount: 10}},
+		{"checked to", []game.Choice{{Type: action.Call}, {Type: action.Bet, Min: 10, Max: 100}}, action.Action{Type: action.Call}},
+		{"drawing", []game.Choice{{Type: action.Draw}}, action.Action{Type: action.Draw}},
+	}
+	for _, tt := range tests {
+		if a := (AlwaysCall{}).Act(&game.View{}, tt.choices); a.Type != tt.expected.Type || a.Amount != tt.expected.Amount || len(a.Cards) != 0 {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, *a)
+		}
+	}
+}
+
+func TestPlayBettingRound(t *testing.T) {
+	g := game.NewGame(newPlayers(4), 5, 10)
+	strategies := make(map[string]Strategy)
+	for _, p := range g.Players {
+		strategies[p.ID] = AlwaysCall{}
+	}
+	g.StartHand()
+	PlayBettingRound(g, strategies)
+	for _, p := range g.Players {
+		if !p.Active || p.Bet != 10 {
+			t.Errorf("Expected %s to call the big blind, got a bet of %d", p.Name, p.Bet)
+		}
+	}
+
+	// Without a strategy a player folds
+	delete(strategies, g.ActionOrder()[0].ID)
+	g.DealStreet()
+	PlayBettingRound(g, strategies)
+	if stillIn(g) != 3 || g.Pot.Chips != 40 {
+		t.Errorf("Expected one fold and no bets, got %d players in and a pot of %d", stillIn(g), g.Pot.Chips)
+	}
+}
+
+// TestRandomPlaysLegally plays hands of every kind of game with random
+// strategies, which must only take actions the game accepts.
+func TestRandomPlaysLegally(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	omaha, _ := game.NewOmahaHiLoGame(newPlayers(4), 5, 10, 4)
+	games := []*game.Game{
+		game.NewGame(newPlayers(6), 5, 10),
+		omaha,
+		game.NewStudGame(newPlayers(5), 1, 2, 10, 20),
+		game.NewTripleDrawGame(newPlayers(3), 5, 10),
+	}
+	for _, g := range games {
+		g.StrictChips = true
+		random := &foldTracker{strategy: NewRandom(rng)}
+		strategies := make(map[string]Strategy)
+		for _, p := range g.Players {
+			strategies[p.ID] = random
+		}
+		for hand := 0; hand < 20; hand++ {
+			random.folded = make(map[string]bool)
+			g.StartHand()
+			PlayBettingRound(g, strategies)
+			for street := 1; street < len(g.Variant.Streets()); street++ {
+				g.DealStreet()
+				PlayBettingRound(g, strategies)
+			}
+			for _, p := range g.Players {
+				if p.Folded && !random.folded[p.ID] {
+					t.Errorf("%s: %s was folded after an illegal action", g.Variant, p.Name)
+				}
+			}
+			g.EndHand()
+		}
+	}
+}
+
+// foldTracker records the players its strategy chooses to fold, so folds
+// forced by rejected actions can be told apart.
+type foldTracker struct {
+	strategy Strategy
+	folded   map[string]bool
+}
+
+func (f *foldTracker) Act(view *game.View, choices []game.Choice) *action.Action {
+	a := f.strategy.Act(view, choices)
+	if a.Type == action.Fold {
+		f.folded[view.PlayerID] = true
+	}
+	return a
+}
+
+func TestEquity(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	g := game.NewGame(newPlayers(2), 5, 10)
+	g.StartHand()
+	alice, bob := g.Players[0], g.Players[1]
+
+	tests := []struct {
+		name     string
+		hand     []*card.Card
+		min, max float64
+	}{
+		{"pocket aces", []*card.Card{card.NewCard(card.Spades, card.Ace), card.NewCard(card.Hearts, card.Ace)}, 0.8, 0.9},
+		{"seven-deuce", []*card.Card{card.NewCard(card.Spades, card.Seven), card.NewCard(card.Hearts, card.Two)}, 0.27, 0.4},
+	}
+	for _, tt := range tests {
+		alice.Hand = tt.hand
+		if equity := Equity(g.View(alice), 2000, rng); equity < tt.min || equity > tt.max {
+			t.Errorf("%s: expected equity between %.2f and %.2f, got %.3f", tt.name, tt.min, tt.max, equity)
+		}
+	}
+
+	bob.Fold()
+	if equity := Equity(g.View(alice), 10, rng); equity != 1 {
+		t.Errorf("Expected the last player in to win the whole pot, got %.3f", equity)
+	}
+}
+
+func TestTightAggressive(t *testing.T) {
+	bot := NewTightAggressive(rand.New(rand.NewSource(1)))
+	g := game.NewGame(newPlayers(2), 5, 10)
+
+	tests := []struct {
+		name     string
+		hand     []*card.Card
+		expected action.ActionType
+	}{
+		{"raises pocket aces", []*card.Card{card.NewCard(card.Spades, card.Ace), card.NewCard(card.Hearts, card.Ace)}, action.Raise},
+		{"folds seven-deuce", []*card.Card{card.NewCard(card.Spades, card.Seven), card.NewCard(card.Hearts, card.Two)}, action.Fold},
+	}
+	for _, tt := range tests {
+		g.StartHand()
+		first := g.ActionOrder()[0]
+		first.Hand = tt.hand
+		a := bot.Act(g.View(first), g.LegalActions(first))
+		if a.Type != tt.expected {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.expected, a.Type)
+		}
+		if err := g.PerformAction(first, a); err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+		}
+		g.EndHand()
+	}
+}
+
+func TestDiscards(t *testing.T) {
+	hand := []*card.Card{
+		card.NewCard(card.Spades, card.Two),
+		card.NewCard(card.Hearts, card.Seven),
+		card.NewCard(card.Clubs, card.Seven),
+		card.NewCard(card.Diamonds, card.King),
+		card.NewCard(card.Spades, card.Four),
+	}
+	tests := []struct {
+		name     string
+		variant  game.Variant
+		expected []*card.Card
+	}{
+		{"pair in five-card draw", game.FiveCardDraw, []*card.Card{hand[0], hand[4]}},
+		{"deuce-to-seven", game.DeuceSevenTripleDraw, []*card.Card{hand[2], hand[3]}},
+	}
+	for _, tt := range tests {
+		got := discards(&game.View{Variant: tt.variant, Hand: hand})
+		if len(got) != len(tt.expected) {
+			t.Errorf("%s: expected to discard %v, got %v", tt.name, tt.expected, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.expected[i] {
+				t.Errorf("%s: expected to discard %v, got %v", tt.name, tt.expected, got)
+			}
+		}
+	}
+}
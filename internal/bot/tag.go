@@ -0,0 +1,264 @@
+package bot
+
+import (
+	"math/rand"
+	"sync"
+
+	"github.com/prfc0/aksha/internal/action"
+	"github.com/prfc0/aksha/internal/card"
+	"github.com/prfc0/aksha/internal/game"
+	"github.com/prfc0/aksha/internal/hand"
+)
+
+// TightAggressive is a rule-based strategy that plays few hands and bets
+// the ones it plays. It estimates its share of the pot against the hands
+// still in and raises strong hands, calls when the price is right and
+// folds the rest, playing tighter before the first card is dealt after the
+// opening deal.
+type TightAggressive struct {
+	Samples int // Deals sampled to estimate hand strength; more are slower but play more accurately
+
+	rng *rand.Rand
+}
+
+// DefaultSamples is the number of deals a TightAggressive strategy samples
+// unless told otherwise.
+const DefaultSamples = 50
+
+// NewTightAggressive creates a TightAggressive strategy drawing from rng.
+func NewTightAggressive(rng *rand.Rand) *TightAggressive {
+	return &TightAggressive{Samples: DefaultSamples, rng: rng}
+}
+
+func (b *TightAggressive) Act(view *game.View, choices []game.Choice) *action.Action {
+	if view.Drawing {
+		return action.NewDraw(discards(view))
+	}
+
+	equity := Equity(view, b.Samples, b.rng)
+	fairShare := 1 / float64(opponents(view)+1)
+	potOdds := float64(view.ToCall) / float64(view.Pot+view.ToCall)
+	tightness := 0.9
+	if view.BettingRound == 0 {
+		tightness = 1.2
+	}
+
+	raise, canRaise := find(choices, action.Raise)
+	if !canRaise {
+		raise, canRaise = find(choices, action.Bet)
+	}
+	switch {
+	case canRaise && equity >= min(1.6*fairShare, 0.7):
+		// Bet three-quarters of the pot after calling
+		amount := view.ToCall + (view.Pot+view.ToCall)*3/4
+		return action.NewAction(raise.Type, max(raise.Min, min(amount, raise.Max)))
+	case view.ToCall == 0:
+		return action.NewAction(action.Call, 0)
+	case equity >= potOdds && equity >= tightness*fairShare:
+		return action.NewAction(action.Call, view.ToCall)
+	default:
+		return action.NewAction(action.Fold, 0)
+	}
+}
+
+// opponents returns the number of other players still in the hand.
+func opponents(view *game.View) int {
+	count := 0
+	for _, seat := range view.Players {
+		if seat.Active && seat.ID != view.PlayerID {
+			count++
+		}
+	}
+	return count
+}
+
+// discards chooses the cards to draw to. In deuce-to-seven it keeps the
+// unpaired cards of eight or lower; otherwise it stands pat on a straight
+// or better and keeps its pairs and best other card.
+func discards(view *game.View) []*card.Card {
+	counts := make(map[card.Rank]int)
+	for _, holeCard := range view.Hand {
+		counts[holeCard.Rank]++
+	}
+
+	discards := make([]*card.Card, 0)
+	if high, _ := view.Variant.Evaluators(); high == hand.DeuceToSeven {
+		kept := make(map[card.Rank]bool)
+		for _, holeCard := range view.Hand {
+			if holeCard.Rank > card.Eight || kept[holeCard.Rank] {
+				discards = append(discards, holeCard)
+			} else {
+				kept[holeCard.Rank] = true
+			}
+		}
+		return discards
+	}
+
+	if hand.BestHand(view.Hand).Rank >= hand.Straight {
+		return discards
+	}
+	var kicker *card.Card
+	for _, holeCard := range view.Hand {
+		if counts[holeCard.Rank] > 1 {
+			continue
+		}
+		if kicker == nil || holeCard.Rank > kicker.Rank {
+			if kicker != nil {
+				discards = append(discards, kicker)
+			}
+			kicker = holeCard
+		} else {
+			discards = append(discards, holeCard)
+		}
+	}
+	return discards
+}
+
+// Equity estimates the share of the pot the viewer's hand wins against the
+// other hands still in, by dealing the cards they cannot see at random
+// samples times and completing every hand and the board. Split pots are
+// shared between the high and low hands.
+func Equity(view *game.View, samples int, rng *rand.Rand) float64 {
+	if opponents(view) == 0 {
+		return 1
+	}
+	high, low := view.Variant.Evaluators()
+	holeCards, boardSize := 0, 0
+	for _, street := range view.Variant.Streets() {
+		holeCards += street.Down + street.Up
+		boardSize += street.Board
+	}
+
+	seen := make([]*card.Card, 0)
+	seen = append(seen, view.Board...)
+	for _, seat := range view.Players {
+		seen = append(seen, seat.Hand...)
+	}
+	unseen := make([]*card.Card, 0)
+	for _, deckCard := range fullDeck(view.Variant) {
+		if !containsCard(seen, deckCard) {
+			unseen = append(unseen, deckCard)
+		}
+	}
+
+	total := 0.0
+	for sample := 0; sample < samples; sample++ {
+		rng.Shuffle(len(unseen), func(i, j int) {
+			unseen[i], unseen[j] = unseen[j], unseen[i]
+		})
+		remaining := unseen
+		deal := func() *card.Card {
+			if len(remaining) == 0 {
+				return nil
+			}
+			dealt := remaining[0]
+			remaining = remaining[1:]
+			return dealt
+		}
+
+		board := completeHand(view.Board, boardSize, deal)
+		hands := make([][]*card.Card, 0, len(view.Players))
+		viewer := 0
+		for _, seat := range view.Players {
+			if !seat.Active {
+				continue
+			}
+			if seat.ID == view.PlayerID {
+				viewer = len(hands)
+			}
+			hands = append(hands, completeHand(seat.Hand, holeCards, deal))
+		}
+
+		highShare := share(high, hands, board, viewer)
+		if low == nil {
+			total += highShare
+			continue
+		}
+		if lowShare := share(low, hands, board, viewer); lowShare >= 0 {
+			total += (highShare + lowShare) / 2
+		} else {
+			total += highShare
+		}
+	}
+	return total / float64(samples)
+}
+
+// decks holds the cards of each variant's deck, by variant, so that they
+// are not dealt out afresh for every decision.
+var decks sync.Map
+
+// fullDeck returns every card in the variant's deck. The cards are shared
+// and must not be changed.
+func fullDeck(v game.Variant) []*card.Card {
+	if cards, ok := decks.Load(v); ok {
+		return cards.([]*card.Card)
+	}
+	cards, _ := decks.LoadOrStore(v, v.NewDeck().Cards)
+	return cards.([]*card.Card)
+}
+
+// completeHand fills in the hidden cards of a hand, or board, and deals it
+// up to the given number of cards while the deck lasts.
+func completeHand(visible []*card.Card, size int, deal func() *card.Card) []*card.Card {
+	cards := make([]*card.Card, 0, size)
+	for _, visibleCard := range visible {
+		if visibleCard == nil {
+			visibleCard = deal()
+		}
+		if visibleCard != nil {
+			cards = append(cards, visibleCard)
+		}
+	}
+	for len(cards) < size {
+		dealt := deal()
+		if dealt == nil {
+			break
+		}
+		cards = append(cards, dealt)
+	}
+	return cards
+}
+
+// share returns the viewer's share of a pot ranked by evaluator, or -1 if
+// no hand qualifies for it.
+func share(evaluator hand.Evaluator, hands [][]*card.Card, board []*card.Card, viewer int) float64 {
+	var best hand.Ranked
+	winners := make([]int, 0)
+	for i, cards := range hands {
+		ranked := evaluator.Evaluate(cards, board)
+		if ranked == nil {
+			continue
+		}
+		comparison := 1
+		if best != nil {
+			comparison = evaluator.Compare(ranked, best)
+		}
+		switch {
+		case comparison > 0:
+			best = ranked
+			winners = []int{i}
+		case comparison == 0:
+			winners = append(winners, i)
+		}
+	}
+	if len(winners) == 0 {
+		return -1
+	}
+	for _, winner := range winners {
+		if winner == viewer {
+			return 1 / float64(len(winners))
+		}
+	}
+	return 0
+}
+
+// containsCard reports whether cards holds a card of the same rank and suit
+// as c.
+func containsCard(cards []*card.Card, c *card.Card) bool {
+	for _, other := range cards {
+		if other != nil && *other == *c {
+			return true
+		}
+	}
+	return false
+}
@@ -40,3 +40,12 @@ func TestRankToString(t *testing.T) {
 		}
 	}
 }
+
+func TestSuitOrder(t *testing.T) {
+	suits := []Suit{Clubs, Diamonds, Hearts, Spades}
+	for i := 1; i < len(suits); i++ {
+		if suits[i].Order() <= suits[i-1].Order() {
+			t.Errorf("Expected %s to rank above %s", suits[i].LongName, suits[i-1].LongName)
+		}
+	}
+}
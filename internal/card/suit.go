@@ -11,3 +11,20 @@ var (
 	Diamonds = Suit{"Diamonds", "d"}
 	Clubs    = Suit{"Clubs", "c"}
 )
+
+// Order ranks suits for breaking ties, such as the stud bring-in: clubs,
+// diamonds, hearts, then spades highest.
+func (s Suit) Order() int {
+	switch s {
+	case Clubs:
+		return 1
+	case Diamonds:
+		return 2
+	case Hearts:
+		return 3
+	case Spades:
+		return 4
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,236 @@
+package cfr
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/prfc0/aksha/internal/card"
+)
+
+// Profile is a strategy for both players of Kuhn poker: for each infoset,
+// named by the acting player's card and the betting so far, the
+// probabilities of passing and betting. Infosets it leaves out are played
+// uniformly at random.
+type Profile map[string][2]float64
+
+// probabilities returns the probabilities of passing and betting at an
+// infoset.
+func (p Profile) probabilities(key string) [2]float64 {
+	if probabilities, ok := p[key]; ok {
+		return probabilities
+	}
+	return [2]float64{0.5, 0.5}
+}
+
+func (p Profile) String() string {
+	keys := make([]string, 0, len(p))
+	for key := range p {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	s := ""
+	for _, key := range keys {
+		s += fmt.Sprintf("%-4s pass %.3f bet %.3f\n", key, p[key][0], p[key][1])
+	}
+	return s
+}
+
+// Value returns what the first player wins per hand, on average, when both
+// players play the profile. At equilibrium it is -1/18.
+func (p Profile) Value() float64 {
+	return value(p, p)
+}
+
+// Exploitability returns how much, on average, players who knew the profile
+// would win per hand by playing their best response to it instead: half of
+// what the two best responses gain together. It is 0 only at equilibrium.
+func (p Profile) Exploitability() float64 {
+	bestFirst, bestSecond := value(bestResponse(p, 0), p), value(p, bestResponse(p, 1))
+	return (bestFirst - bestSecond) / 2
+}
+
+// value returns what the first player wins per hand, on average, when they
+// play first and the second player plays second.
+func value(first, second Profile) float64 {
+	total := 0.0
+	deals := deals()
+	for _, cards := range deals {
+		total += walk(first, second, cards, "")
+	}
+	return total / float64(len(deals))
+}
+
+// walk returns what the first player wins, on average, from a point in a
+// hand.
+func walk(first, second Profile, cards [2]card.Rank, history string) float64 {
+	if won, over := payoff(cards, history); over {
+		return won
+	}
+	player, profile := len(history)%2, first
+	if player == 1 {
+		profile = second
+	}
+	probabilities := profile.probabilities(infosetKey(cards[player], history))
+	total := 0.0
+	for i, move := range moves {
+		if probabilities[i] > 0 {
+			total += probabilities[i] * walk(first, second, cards, history+string(move))
+		}
+	}
+	return total
+}
+
+// bestResponse returns the profile in which player plays the pure strategy
+// that wins the most against the other player's part of p. Kuhn poker is
+// small enough to try every pure strategy.
+func bestResponse(p Profile, player int) Profile {
+	keys := make([]string, 0)
+	for _, rank := range kuhnCards {
+		for _, history := range []string{"", "p", "b", "pb"} {
+			if len(history)%2 == player {
+				keys = append(keys, infosetKey(rank, history))
+			}
+		}
+	}
+
+	var best Profile
+	bestValue := 0.0
+	for choice := 0; choice < 1<<len(keys); choice++ {
+		response := make(Profile)
+		for i, key := range keys {
+			response[key] = [2]float64{1, 0}
+			if choice&(1<<i) != 0 {
+				response[key] = [2]float64{0, 1}
+			}
+		}
+
+		won := value(response, p)
+		if player == 1 {
+			won = -value(p, response)
+		}
+		if best == nil || won > bestValue {
+			best, bestValue = response, won
+		}
+	}
+	return best
+}
+
+// infoset is what the solver learns about one infoset.
+type infoset struct {
+	regretSum   [2]float64 // Regret for not having passed and bet, summed over iterations
+	strategySum [2]float64 // Probabilities of passing and betting, summed over iterations by reach
+	current     [2]float64 // Probabilities of passing and betting played in the current walk
+}
+
+// strategy returns the current probabilities of passing and betting, in
+// proportion to the positive regrets.
+func (i *infoset) strategy() [2]float64 {
+	positive := [2]float64{max(i.regretSum[0], 0), max(i.regretSum[1], 0)}
+	total := positive[0] + positive[1]
+	if total == 0 {
+		return [2]float64{0.5, 0.5}
+	}
+	return [2]float64{positive[0] / total, positive[1] / total}
+}
+
+// Solver finds an equilibrium of Kuhn poker by counterfactual regret
+// minimization. Each iteration walks the whole game tree once for each
+// player, updating that player's regrets in turn.
+type Solver struct {
+	Plus       bool // Whether to train with CFR+: regrets floored at zero and later iterations weighted more
+	Iterations int  // Iterations trained so far
+
+	infosets map[string]*infoset
+}
+
+// NewSolver creates a solver, using CFR+ if plus is set.
+func NewSolver(plus bool) *Solver {
+	return &Solver{Plus: plus, infosets: make(map[string]*infoset)}
+}
+
+// Train runs iterations more iterations of the solver. If report is not
+// nil it is called with the exploitability of the average strategy every
+// reportEvery iterations.
+//
+// Each player's walk plays the strategy the regrets gave when it started,
+// so the regrets it adds up do not change the strategy partway through.
+// CFR+ floors the regrets once the walk is over.
+func (s *Solver) Train(iterations, reportEvery int, report func(iteration int, exploitability float64)) {
+	for i := 0; i < iterations; i++ {
+		s.Iterations++
+		weight := 1.0
+		if s.Plus {
+			weight = float64(s.Iterations)
+		}
+		for traverser := 0; traverser < 2; traverser++ {
+			for _, info := range s.infosets {
+				info.current = info.strategy()
+			}
+			for _, cards := range deals() {
+				s.cfr(cards, "", [2]float64{1, 1}, traverser, weight)
+			}
+			if s.Plus {
+				for _, info := range s.infosets {
+					info.regretSum = [2]float64{max(info.regretSum[0], 0), max(info.regretSum[1], 0)}
+				}
+			}
+		}
+		if report != nil && reportEvery > 0 && s.Iterations%reportEvery == 0 {
+			report(s.Iterations, s.Profile().Exploitability())
+		}
+	}
+}
+
+// cfr walks the game tree from a point in a hand reached with the players'
+// probabilities in reach, updating the traverser's regrets and average
+// strategy, and returns what the first player wins there on average.
+func (s *Solver) cfr(cards [2]card.Rank, history string, reach [2]float64, traverser int, weight float64) float64 {
+	if won, over := payoff(cards, history); over {
+		return won
+	}
+	player := len(history) % 2
+	key := infosetKey(cards[player], history)
+	info, ok := s.infosets[key]
+	if !ok {
+		info = &infoset{current: [2]float64{0.5, 0.5}}
+		s.infosets[key] = info
+	}
+
+	strategy := info.current
+	var values [2]float64
+	total := 0.0
+	for i, move := range moves {
+		next := reach
+		next[player] *= strategy[i]
+		values[i] = s.cfr(cards, history+string(move), next, traverser, weight)
+		total += strategy[i] * values[i]
+	}
+	if player != traverser {
+		return total
+	}
+
+	// Values are the first player's, so the second player's regrets are
+	// for the negated values
+	sign := 1.0
+	if player == 1 {
+		sign = -1
+	}
+	for i := range moves {
+		info.regretSum[i] += sign * reach[1-player] * (values[i] - total)
+		info.strategySum[i] += weight * reach[player] * strategy[i]
+	}
+	return total
+}
+
+// Profile returns the average strategy over the iterations trained, which
+// converges to an equilibrium.
+func (s *Solver) Profile() Profile {
+	profile := make(Profile)
+	for key, info := range s.infosets {
+		total := info.strategySum[0] + info.strategySum[1]
+		if total > 0 {
+			profile[key] = [2]float64{info.strategySum[0] / total, info.strategySum[1] / total}
+		}
+	}
+	return profile
+}
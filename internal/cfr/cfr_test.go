@@ -0,0 +1,148 @@
+package cfr
+
+import (
+	"io"
+	"log"
+	"math"
+	"math/rand"
+	"os"
+	"testing"
+
+	"github.com/prfc0/aksha/internal/action"
+	"github.com/prfc0/aksha/internal/bot"
+	"github.com/prfc0/aksha/internal/player"
+)
+
+func TestMain(m *testing.M) {
+	// Every hand played through the engine logs every action
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+func TestSolver(t *testing.T) {
+	exploitability := make(map[bool]float64)
+	for _, plus := range []bool{false, true} {
+		solver := NewSolver(plus)
+		reports := make([]float64, 0)
+		solver.Train(2000, 1000, func(iteration int, exploitability float64) {
+			reports = append(reports, exploitability)
+		})
+		if len(reports) != 2 || reports[1] >= reports[0] || reports[1] > 0.001 {
+			t.Errorf("CFR+ %v: expected exploitability to fall below 0.001, got %v", plus, reports)
+		}
+		exploitability[plus] = reports[1]
+
+		profile := solver.Profile()
+		if value := profile.Value(); math.Abs(value+1.0/18) > 0.002 {
+			t.Errorf("CFR+ %v: expected a game value of -1/18, got %f", plus, value)
+		}
+
+		// At equilibrium a king always calls and a jack always folds, and
+		// the first player bets a king three times as often as a jack
+		tests := []struct {
+			key  string
+			bets float64
+		}{
+			{"Kb", 1},
+			{"Kpb", 1},
+			{"Jb", 0},
+			{"Jpb", 0},
+			{"K", 3 * profile["J"][1]},
+		}
+		for _, tt := range tests {
+			if bets := profile[tt.key][1]; math.Abs(bets-tt.bets) > 0.05 {
+				t.Errorf("CFR+ %v: expected %s to bet %.2f, got %.2f", plus, tt.key, tt.bets, bets)
+			}
+		}
+	}
+
+	if exploitability[true] > 0.0001 || exploitability[true] >= exploitability[false]/5 {
+		t.Errorf("Expected CFR+ to converge faster than CFR, got exploitability %f against %f", exploitability[true], exploitability[false])
+	}
+}
+
+func TestExploitability(t *testing.T) {
+	// Always betting is punished by folding jacks and calling kings, and
+	// always passing by bluffing
+	tests := []struct {
+		name    string
+		profile Profile
+		min     float64
+	}{
+		{"always bet", alwaysPlay(1), 0.1},
+		{"always pass", alwaysPlay(0), 0.1},
+	}
+	for _, tt := range tests {
+		if exploitability := tt.profile.Exploitability(); exploitability < tt.min {
+			t.Errorf("%s: expected exploitability of at least %.2f, got %f", tt.name, tt.min, exploitability)
+		}
+	}
+}
+
+// alwaysPlay returns the profile that bets at every infoset with
+// probability bets.
+func alwaysPlay(bets float64) Profile {
+	profile := make(Profile)
+	for _, rank := range kuhnCards {
+		for _, history := range []string{"", "p", "b", "pb"} {
+			profile[infosetKey(rank, history)] = [2]float64{1 - bets, bets}
+		}
+	}
+	return profile
+}
+
+func TestPolicyPlaysKuhn(t *testing.T) {
+	solver := NewSolver(true)
+	solver.Train(1000, 0, nil)
+	rng := rand.New(rand.NewSource(1))
+
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+	}
+	g := NewKuhnGame(players)
+	g.Seed(1)
+	g.StrictChips = true
+	strategies := map[string]bot.Strategy{
+		"1": NewPolicy(solver.Profile(), rng),
+		"2": NewPolicy(solver.Profile(), rng),
+	}
+	for hand := 0; hand < 500; hand++ {
+		bot.PlayHand(g, strategies)
+		for _, move := range g.History {
+			if move.Action.Type != action.Call && move.Action.Type != action.Bet && move.Action.Type != action.Fold {
+				t.Fatalf("Unexpected action in Kuhn poker: %v", *move.Action)
+			}
+		}
+		if len(g.History) < 2 || len(g.History) > 3 {
+			t.Fatalf("Expected a Kuhn betting round of two or three actions, got %d", len(g.History))
+		}
+		if players[0].Stack+players[1].Stack != 2000 {
+			t.Fatalf("Expected no chips to be lost, got %d and %d", players[0].Stack, players[1].Stack)
+		}
+	}
+	if players[0].Stack == 1000 {
+		t.Error("Expected chips to change hands")
+	}
+}
+
+func TestKuhnRejectsRaise(t *testing.T) {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 10),
+		player.NewPlayer("2", "Bob", 10),
+	}
+	g := NewKuhnGame(players)
+	if err := g.StartHand(); err != nil {
+		t.Fatalf("Unexpected error starting the hand: %v", err)
+	}
+	order := g.ActionOrder()
+	if err := g.PerformAction(order[0], action.NewAction(action.Bet, 1)); err != nil {
+		t.Fatalf("Unexpected error betting: %v", err)
+	}
+	if err := g.PerformAction(order[1], action.NewAction(action.Raise, 2)); err == nil {
+		t.Error("Expected error raising a bet in Kuhn poker")
+	}
+	if choices := g.LegalActions(order[1]); len(choices) != 2 {
+		t.Errorf("Expected only a fold or a call, got %v", choices)
+	}
+}
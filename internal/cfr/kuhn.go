@@ -0,0 +1,99 @@
+package cfr
+
+import (
+	"github.com/prfc0/aksha/internal/card"
+	"github.com/prfc0/aksha/internal/deck"
+	"github.com/prfc0/aksha/internal/game"
+	"github.com/prfc0/aksha/internal/hand"
+	"github.com/prfc0/aksha/internal/player"
+)
+
+// Kuhn poker is played with a jack, a queen and a king. Both players ante
+// one chip and are dealt one card, and there is a single betting round in
+// which one bet of one chip may be made. The first player acts first.
+var kuhnCards = []card.Rank{card.Jack, card.Queen, card.King}
+
+// Moves in Kuhn poker, as they appear in a betting history: a pass is a
+// check or fold, and a bet is a bet or call.
+const (
+	pass = 'p'
+	bet  = 'b'
+)
+
+var moves = []byte{pass, bet}
+
+// Kuhn is Kuhn poker as a game variant the engine can deal.
+var Kuhn game.Variant = kuhnVariant{&game.Rules{
+	Name:         "Kuhn Poker",
+	Deal:         []game.Street{{Down: 1}},
+	BettingLimit: game.FixedLimit,
+	SmallBets:    1,
+	High:         hand.High,
+}}
+
+// kuhnVariant deals Kuhn poker from its three-card deck.
+type kuhnVariant struct {
+	*game.Rules
+}
+
+func (kuhnVariant) NewDeck() *deck.Deck {
+	d := &deck.Deck{}
+	for _, rank := range kuhnCards {
+		d.Cards = append(d.Cards, card.NewCard(card.Spades, rank))
+	}
+	return d
+}
+
+// NewKuhnGame initializes a game of Kuhn poker between two players. The
+// one bet allowed cannot be raised.
+func NewKuhnGame(players []*player.Player) *game.Game {
+	g := game.NewVariantGame(Kuhn, players, 0, 0)
+	g.Ante = 1
+	g.SmallBet = 1
+	g.BigBet = 1
+	g.MaxBets = 1
+	return g
+}
+
+// payoff returns what the first player wins when a hand with the given
+// cards and betting history is over, and whether it is over.
+func payoff(cards [2]card.Rank, history string) (float64, bool) {
+	switch history {
+	case "bp":
+		return 1, true
+	case "pbp":
+		return -1, true
+	case "pp":
+		return showdown(cards, 1), true
+	case "bb", "pbb":
+		return showdown(cards, 2), true
+	}
+	return 0, false
+}
+
+// showdown returns what the first player wins when the pot of stake chips
+// from each player goes to the best card.
+func showdown(cards [2]card.Rank, stake float64) float64 {
+	first := hand.High.Evaluate([]*card.Card{card.NewCard(card.Spades, cards[0])}, nil)
+	second := hand.High.Evaluate([]*card.Card{card.NewCard(card.Spades, cards[1])}, nil)
+	return stake * float64(hand.High.Compare(first, second))
+}
+
+// deals returns every way two cards can be dealt, each as likely.
+func deals() [][2]card.Rank {
+	deals := make([][2]card.Rank, 0)
+	for _, first := range kuhnCards {
+		for _, second := range kuhnCards {
+			if first != second {
+				deals = append(deals, [2]card.Rank{first, second})
+			}
+		}
+	}
+	return deals
+}
+
+// infosetKey names what a player knows when they act: their card and the
+// betting so far.
+func infosetKey(c card.Rank, history string) string {
+	return c.String() + history
+}
@@ -0,0 +1,52 @@
+package cfr
+
+import (
+	"math/rand"
+
+	"github.com/prfc0/aksha/internal/action"
+	"github.com/prfc0/aksha/internal/game"
+)
+
+// Policy is a bot strategy that plays Kuhn poker, dealt by the engine, by
+// a profile such as one from a Solver.
+type Policy struct {
+	Profile Profile
+
+	rng *rand.Rand
+}
+
+// NewPolicy creates a Policy playing profile, drawing its random choices
+// from rng.
+func NewPolicy(profile Profile, rng *rand.Rand) *Policy {
+	return &Policy{Profile: profile, rng: rng}
+}
+
+// Act passes or bets with the profile's probabilities for the player's card
+// and the betting so far. A pass checks or folds, and a bet bets or calls.
+func (p *Policy) Act(view *game.View, choices []game.Choice) *action.Action {
+	history := make([]byte, 0, len(view.History))
+	for _, move := range view.History {
+		if move.Action.Type == action.Fold || move.Action.Type == action.Call && move.Action.Amount == 0 {
+			history = append(history, pass)
+		} else {
+			history = append(history, bet)
+		}
+	}
+
+	probabilities := p.Profile.probabilities(infosetKey(view.Hand[0].Rank, string(history)))
+	if p.rng.Float64() >= probabilities[1] {
+		if view.ToCall > 0 {
+			return action.NewAction(action.Fold, 0)
+		}
+		return action.NewAction(action.Call, 0)
+	}
+	if view.ToCall > 0 {
+		return action.NewAction(action.Call, view.ToCall)
+	}
+	for _, choice := range choices {
+		if choice.Type == action.Bet {
+			return action.NewAction(action.Bet, choice.Max)
+		}
+	}
+	return action.NewAction(action.Call, 0)
+}
@@ -10,11 +10,26 @@ import (
 )
 
 type Deck struct {
-	Cards []*card.Card
+	Cards    []*card.Card
+	Discards []*card.Card // Cards thrown away in draw games, reshuffled when the deck runs out
+	Short    bool         // Whether the twos through fives are left out, leaving 36 cards
+	Rand     *rand.Rand   // Source of shuffles; nil seeds one from the time
 }
 
-func NewDeck() *Deck {
+// Option configures a deck made by NewDeck.
+type Option func(*Deck)
+
+// ShortDeck leaves the twos through fives out of the deck, as in short-deck
+// Hold'em.
+func ShortDeck(d *Deck) {
+	d.Short = true
+}
+
+func NewDeck(options ...Option) *Deck {
 	deck := &Deck{}
+	for _, option := range options {
+		option(deck)
+	}
 	suits := []card.Suit{
 		card.Spades,
 		card.Hearts,
@@ -36,6 +51,9 @@ func NewDeck() *Deck {
 		card.King,
 		card.Ace,
 	}
+	if deck.Short {
+		ranks = ranks[4:]
+	}
 
 	for _, suit := range suits {
 		for _, rank := range ranks {
@@ -43,19 +61,33 @@ func NewDeck() *Deck {
 		}
 	}
 
-	log.Println("Created a new deck of 52 cards.")
+	log.Printf("Created a new deck of %d cards.\n", len(deck.Cards))
 	return deck
 }
 
+// Shuffle shuffles the deck with its Rand, or if it has none with the time
+// as the seed.
 func (d *Deck) Shuffle() {
-	rand.Seed(time.Now().UnixNano())
-	rand.Shuffle(len(d.Cards), func(i, j int) {
+	shuffle := rand.Shuffle
+	if d.Rand != nil {
+		shuffle = d.Rand.Shuffle
+	} else {
+		rand.Seed(time.Now().UnixNano())
+	}
+	shuffle(len(d.Cards), func(i, j int) {
 		d.Cards[i], d.Cards[j] = d.Cards[j], d.Cards[i]
 	})
 	log.Println("Shuffled the deck.")
 }
 
+// Draw takes the top card of the deck. When the deck is empty the discards
+// are shuffled to form a new one.
 func (d *Deck) Draw() *card.Card {
+	if len(d.Cards) == 0 && len(d.Discards) > 0 {
+		d.Cards, d.Discards = d.Discards, nil
+		d.Shuffle()
+		log.Printf("Reshuffled %d discards into the deck.\n", len(d.Cards))
+	}
 	if len(d.Cards) == 0 {
 		log.Println("No cards left in the deck.")
 		return nil
@@ -66,7 +98,17 @@ func (d *Deck) Draw() *card.Card {
 	return card
 }
 
+// Discard puts cards on the discard pile.
+func (d *Deck) Discard(cards ...*card.Card) {
+	d.Discards = append(d.Discards, cards...)
+}
+
 func (d *Deck) Reset() {
-	d.Cards = NewDeck().Cards
-	log.Println("Reset the deck to 52 cards.")
+	if d.Short {
+		d.Cards = NewDeck(ShortDeck).Cards
+	} else {
+		d.Cards = NewDeck().Cards
+	}
+	d.Discards = nil
+	log.Printf("Reset the deck to %d cards.\n", len(d.Cards))
 }
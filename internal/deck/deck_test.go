@@ -1,6 +1,7 @@
 package deck
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/prfc0/aksha/internal/card"
@@ -32,6 +33,20 @@ func TestShuffle(t *testing.T) {
 	}
 }
 
+func TestSeededShuffle(t *testing.T) {
+	first, second := NewDeck(), NewDeck()
+	first.Rand = rand.New(rand.NewSource(7))
+	second.Rand = rand.New(rand.NewSource(7))
+	first.Shuffle()
+	second.Shuffle()
+
+	for i := range first.Cards {
+		if *first.Cards[i] != *second.Cards[i] {
+			t.Fatalf("Expected the same seed to shuffle the same way, got %s and %s at %d", first.Cards[i], second.Cards[i], i)
+		}
+	}
+}
+
 func TestDraw(t *testing.T) {
 	deck := NewDeck()
 	card := deck.Draw()
@@ -51,3 +66,35 @@ func TestReset(t *testing.T) {
 		t.Errorf("Expected 52 cards, got %d", len(deck.Cards))
 	}
 }
+
+func TestDrawReshufflesDiscards(t *testing.T) {
+	deck := NewDeck()
+	discards := deck.Cards[:2]
+	deck.Cards = nil
+	deck.Discard(discards...)
+
+	if deck.Draw() == nil || deck.Draw() == nil {
+		t.Fatal("Expected the discards to be reshuffled into the deck")
+	}
+	if len(deck.Discards) != 0 || deck.Draw() != nil {
+		t.Error("Expected no cards left once the discards are used up")
+	}
+}
+
+func TestShortDeck(t *testing.T) {
+	deck := NewDeck(ShortDeck)
+	if len(deck.Cards) != 36 {
+		t.Errorf("Expected 36 cards, got %d", len(deck.Cards))
+	}
+	for _, c := range deck.Cards {
+		if c.Value() < int(card.Six) {
+			t.Errorf("Expected no cards below a six, got %s", c)
+		}
+	}
+
+	deck.Draw()
+	deck.Reset()
+	if len(deck.Cards) != 36 {
+		t.Errorf("Expected a short deck to reset to 36 cards, got %d", len(deck.Cards))
+	}
+}
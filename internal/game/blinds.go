@@ -0,0 +1,170 @@
+package game
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/prfc0/aksha/internal/player"
+)
+
+// StraddleType says which player may post a straddle before the deal.
+type StraddleType int
+
+const (
+	NoStraddle     StraddleType = iota // Straddles are not allowed
+	UTGStraddle                        // The player left of the big blind straddles, later players may re-straddle
+	ButtonStraddle                     // The button straddles and acts last pre-flop
+)
+
+// applySchedule moves the blind schedule on to the level now in play and
+// takes its blinds and antes. During a break the blinds of the level before
+// it stay in force.
+func (g *Game) applySchedule() {
+	g.Schedule.Advance()
+	if g.Schedule.OnBreak() {
+		log.Println("Starting a hand during a break.")
+	}
+	level := g.Schedule.Blinds()
+	g.SmallBlind = level.SmallBlind
+	g.BigBlind = level.BigBlind
+	g.Ante = level.Ante
+	g.BigBlindAnte = level.BigBlindAnte
+}
+
+// PostAntes collects the ante from every player dealt into the hand. Antes
+// are dead money and do not count towards a player's bet.
+func (g *Game) PostAntes() {
+	if g.Ante == 0 {
+		return
+	}
+	for _, player := range g.dealt {
+		g.postDeadChips(player, g.Ante)
+	}
+	log.Printf("Posted antes of %d.\n", g.Ante)
+}
+
+// postBigBlindAnte collects the single ante the big blind posts for the
+// whole table. It is posted after the big blind itself, so a short stack
+// covers the blind first.
+func (g *Game) postBigBlindAnte() {
+	if g.BigBlindAnte == 0 {
+		return
+	}
+	bigBlindPlayer := g.Players[g.BigBlindPosition()]
+	g.postDeadChips(bigBlindPlayer, g.BigBlindAnte)
+	log.Printf("Player %s posted the big blind ante.\n", bigBlindPlayer.Name)
+}
+
+// postButtonAnte collects the extra ante the button posts in a button-ante
+// game, which has no blinds.
+func (g *Game) postButtonAnte() {
+	button := g.ButtonPosition()
+	if button == -1 {
+		return
+	}
+	g.postDeadChips(g.Players[button], g.ButtonAnte)
+	log.Printf("Player %s posted the button ante.\n", g.Players[button].Name)
+}
+
+// postMissedBlinds collects the blinds owed by players returning from sitting
+// out: a missed big blind is posted live and a missed small blind dead. A
+// player returning in the big blind only posts the big blind. A player
+// returning in the small blind only posts the small blind and still owes
+// the big blind, which they post live in the next hand.
+func (g *Game) postMissedBlinds() {
+	for i, player := range g.Players {
+		if !player.Active || (!player.MissedBigBlind && !player.MissedSmallBlind) {
+			continue
+		}
+		switch i {
+		case g.BigBlindPosition():
+			player.MissedBigBlind = false
+		case g.SmallBlindPosition():
+		default:
+			if player.MissedBigBlind {
+				g.postBlind(player, g.BigBlind)
+			}
+			if player.MissedSmallBlind {
+				g.postDeadChips(player, g.SmallBlind)
+			}
+			log.Printf("Player %s posted missed blinds.\n", player.Name)
+			player.MissedBigBlind = false
+		}
+		player.MissedSmallBlind = false
+	}
+}
+
+// NextStraddler returns the position of the player who may post the next
+// straddle, or -1 if no further straddle is allowed.
+func (g *Game) NextStraddler() int {
+	maxStraddles := g.MaxStraddles
+	if maxStraddles == 0 {
+		maxStraddles = 1
+	}
+	if g.Straddle == NoStraddle || g.BettingRound != 0 || len(g.dealt) < 3 || len(g.Straddles) >= maxStraddles {
+		return -1
+	}
+
+	switch g.Straddle {
+	case UTGStraddle:
+		last := g.BigBlindPosition()
+		if len(g.Straddles) > 0 {
+			last = g.positionOf(g.Straddles[len(g.Straddles)-1])
+		}
+		next := g.nextDealtIn(last)
+		if next == g.SmallBlindPosition() {
+			return -1
+		}
+		return next
+	case ButtonStraddle:
+		if len(g.Straddles) > 0 {
+			return -1
+		}
+		return g.ButtonPosition()
+	}
+	return -1
+}
+
+// PostStraddle posts a straddle of twice the current bet for p. It must be
+// called after the blinds and before any player acts.
+func (g *Game) PostStraddle(p *player.Player) error {
+	next := g.NextStraddler()
+	if next == -1 || g.Players[next] != p {
+		return fmt.Errorf("player %s may not straddle now", p.Name)
+	}
+	amount := 2 * g.CurrentBet
+	if p.Stack < amount {
+		return fmt.Errorf("player %s does not have enough chips to straddle %d", p.Name, amount)
+	}
+
+	g.lastRaise = amount - g.CurrentBet
+	g.postBlind(p, amount)
+	g.Straddles = append(g.Straddles, p)
+	log.Printf("Player %s straddled %d.\n", p.Name, amount)
+	g.checkChips("straddling")
+	return nil
+}
+
+// postBlind posts a live blind or straddle of up to amount chips for p.
+func (g *Game) postBlind(p *player.Player, amount int) {
+	g.Pot.AddChips(p.Commit(amount))
+	if p.Bet > g.CurrentBet {
+		g.CurrentBet = p.Bet
+	}
+}
+
+// postDeadChips puts up to amount chips from p into the pot without counting
+// them towards the player's bet.
+func (g *Game) postDeadChips(p *player.Player, amount int) {
+	g.Pot.AddChips(p.CommitDead(amount))
+}
+
+// positionOf returns the position of p at the table, or -1.
+func (g *Game) positionOf(p *player.Player) int {
+	for i, player := range g.Players {
+		if player == p {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,255 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/prfc0/aksha/internal/player"
+	"github.com/prfc0/aksha/internal/schedule"
+	"github.com/prfc0/aksha/internal/table"
+)
+
+func newFourHandedGame() *Game {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+		player.NewPlayer("3", "Charlie", 1000),
+		player.NewPlayer("4", "Dave", 1000),
+	}
+	return NewGame(players, 10, 20)
+}
+
+func TestAntes(t *testing.T) {
+	game := newFourHandedGame()
+	game.Ante = 5
+	game.StartHand()
+
+	if game.Pot.Chips != 4*5+10+20 {
+		t.Errorf("Expected antes and blinds in the pot, got %d", game.Pot.Chips)
+	}
+	if game.Players[2].Bet != 20 || game.Players[2].Stack != 975 {
+		t.Errorf("Expected the ante not to count towards the big blind's bet, got bet %d, stack %d", game.Players[2].Bet, game.Players[2].Stack)
+	}
+	if game.CurrentBet != 20 {
+		t.Errorf("Expected current bet of 20, got %d", game.CurrentBet)
+	}
+}
+
+func TestBigBlindAnte(t *testing.T) {
+	game := newFourHandedGame()
+	game.BigBlindAnte = 20
+	game.Players[2].Stack = 30
+	game.StartHand()
+
+	bigBlind := game.Players[2]
+	if bigBlind.Bet != 20 || bigBlind.Stack != 0 {
+		t.Errorf("Expected a short big blind to cover the blind before the ante, got bet %d, stack %d", bigBlind.Bet, bigBlind.Stack)
+	}
+	if game.Pot.Chips != 10+30 {
+		t.Errorf("Expected 40 chips in the pot, got %d", game.Pot.Chips)
+	}
+	if game.Players[0].Stack != 1000 || game.Players[3].Stack != 1000 {
+		t.Error("Expected only the big blind to post an ante")
+	}
+}
+
+func TestBlindSchedule(t *testing.T) {
+	game := newFourHandedGame()
+	game.Schedule = schedule.NewSchedule([]schedule.Level{
+		{SmallBlind: 25, BigBlind: 50, Hands: 1},
+		{SmallBlind: 50, BigBlind: 100, Ante: 10},
+	})
+
+	game.StartHand()
+	if game.SmallBlind != 25 || game.BigBlind != 50 || game.Pot.Chips != 75 {
+		t.Errorf("Expected the first level's blinds of 25/50, got %d/%d with %d in the pot", game.SmallBlind, game.BigBlind, game.Pot.Chips)
+	}
+	game.EndHand()
+
+	game.StartHand()
+	if game.SmallBlind != 50 || game.BigBlind != 100 || game.Ante != 10 {
+		t.Errorf("Expected the second level's blinds of 50/100 ante 10, got %d/%d ante %d", game.SmallBlind, game.BigBlind, game.Ante)
+	}
+}
+
+func TestUTGStraddles(t *testing.T) {
+	game := newFourHandedGame()
+	game.Straddle = UTGStraddle
+	game.StraddleForced = true
+	game.MaxStraddles = 2
+	game.StartHand()
+
+	if len(game.Straddles) != 1 || game.Straddles[0].Name != "Dave" || game.CurrentBet != 40 {
+		t.Fatalf("Expected Dave to post a mandatory straddle of 40, got %v at %d", game.Straddles, game.CurrentBet)
+	}
+
+	if err := game.PostStraddle(game.Players[1]); err == nil {
+		t.Error("Expected an error when a player out of turn straddles")
+	}
+	if err := game.PostStraddle(game.Players[0]); err != nil {
+		t.Fatalf("Unexpected error re-straddling: %v", err)
+	}
+	if game.CurrentBet != 80 || game.Pot.Chips != 10+20+40+80 {
+		t.Errorf("Expected a re-straddle of 80, got current bet %d and pot %d", game.CurrentBet, game.Pot.Chips)
+	}
+	if game.NextStraddler() != -1 {
+		t.Error("Expected no further straddles to be allowed")
+	}
+
+	order := game.ActionOrder()
+	names := []string{"Bob", "Charlie", "Dave", "Alice"}
+	for i, name := range names {
+		if order[i].Name != name {
+			t.Errorf("Expected %s to act %d pre-flop, got %s", name, i+1, order[i].Name)
+		}
+	}
+}
+
+func TestButtonStraddle(t *testing.T) {
+	game := newFourHandedGame()
+	game.Straddle = ButtonStraddle
+	game.StartHand()
+
+	if err := game.PostStraddle(game.Players[0]); err != nil {
+		t.Fatalf("Unexpected error posting a button straddle: %v", err)
+	}
+
+	order := game.ActionOrder()
+	if order[0].Name != "Dave" || order[len(order)-1].Name != "Alice" {
+		t.Errorf("Expected action to start left of the big blind and end on the button, got %v", order)
+	}
+}
+
+func TestNoStraddleHeadsUp(t *testing.T) {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+	}
+	game := NewGame(players, 10, 20)
+	game.Straddle = UTGStraddle
+	game.StartHand()
+
+	if game.NextStraddler() != -1 {
+		t.Error("Expected straddles not to be allowed heads-up")
+	}
+}
+
+func TestMissedBlindsPosted(t *testing.T) {
+	seats := table.NewTable(6)
+	for _, player := range newFourHandedGame().Players {
+		seats.AddPlayer(player)
+	}
+	seats.AddPlayer(player.NewPlayer("5", "Eve", 1000))
+	game := NewGame(seats.Players, 10, 20)
+	game.Table = seats
+	alice := seats.PlayerAt(0)
+
+	game.StartHand()
+	game.EndHand()
+	game.StartHand()
+	game.EndHand()
+
+	alice.SitOut()
+	game.StartHand()
+	if alice.Active || len(alice.Hand) != 0 {
+		t.Error("Expected a player sitting out not to be dealt in")
+	}
+	game.EndHand()
+	if !alice.MissedBigBlind || !alice.MissedSmallBlind {
+		t.Fatal("Expected Alice to have missed the blinds")
+	}
+
+	alice.SitIn()
+	stack := alice.Stack
+	game.StartHand()
+	if alice.Bet != 20 || alice.Stack != stack-30 {
+		t.Errorf("Expected Alice to post a live big blind and a dead small blind, got bet %d, paid %d", alice.Bet, stack-alice.Stack)
+	}
+	if alice.MissedBigBlind || alice.MissedSmallBlind {
+		t.Error("Expected missed blinds to be cleared once posted")
+	}
+	if game.Pot.Chips != 10+20+20+10 {
+		t.Errorf("Expected 60 chips in the pot, got %d", game.Pot.Chips)
+	}
+}
+
+func TestMissedBlindsReturningInSmallBlind(t *testing.T) {
+	seats := table.NewTable(6)
+	for _, player := range newFourHandedGame().Players {
+		seats.AddPlayer(player)
+	}
+	game := NewGame(seats.Players, 10, 20)
+	game.Table = seats
+	game.StartHand()
+	returning := game.Players[game.BigBlindPosition()]
+	game.EndHand()
+
+	// The player returns owing both blinds and is due the small blind
+	returning.MissedBigBlind, returning.MissedSmallBlind = true, true
+	stack := returning.Stack
+	game.StartHand()
+	if game.Players[game.SmallBlindPosition()] != returning || stack-returning.Stack != 10 {
+		t.Fatalf("Expected %s to post only the small blind, paid %d", returning.Name, stack-returning.Stack)
+	}
+	if !returning.MissedBigBlind || returning.MissedSmallBlind {
+		t.Error("Expected the missed big blind to be owed until it is paid")
+	}
+	game.EndHand()
+
+	// On the button next hand they post the big blind they owe, live
+	stack = returning.Stack
+	game.StartHand()
+	if returning.Bet != 20 || stack-returning.Stack != 20 {
+		t.Errorf("Expected %s to post a live big blind, got bet %d, paid %d", returning.Name, returning.Bet, stack-returning.Stack)
+	}
+	if returning.MissedBigBlind {
+		t.Error("Expected the missed big blind to be cleared once paid")
+	}
+}
+
+func TestDeadSmallBlindNotPosted(t *testing.T) {
+	seats := table.NewTable(6)
+	for _, player := range newFourHandedGame().Players {
+		seats.AddPlayer(player)
+	}
+	game := NewGame(seats.Players, 10, 20)
+	game.Table = seats
+	game.StartHand()
+	game.EndHand()
+
+	// The big blind leaves, so nobody posts the small blind next hand
+	seats.RemovePlayer(seats.PlayerAt(seats.BigBlindSeat).ID)
+	game.StartHand()
+	if game.SmallBlindPosition() != -1 || game.Pot.Chips != 20 {
+		t.Errorf("Expected only the big blind to be posted, got pot %d", game.Pot.Chips)
+	}
+	if game.ButtonPosition() == -1 {
+		t.Error("Expected the button to stay live")
+	}
+}
+
+func TestTooFewPlayersAtTable(t *testing.T) {
+	seats := table.NewTable(6)
+	alice := player.NewPlayer("1", "Alice", 1000)
+	seats.AddPlayer(alice)
+	game := NewGame(seats.Players, 10, 20)
+	game.Table = seats
+
+	if err := game.StartHand(); err == nil {
+		t.Error("Expected error starting a hand with one player at the table")
+	}
+	if alice.Stack != 1000 || game.Pot.Chips != 0 {
+		t.Errorf("Expected no blinds to be posted, got stack %d and pot %d", alice.Stack, game.Pot.Chips)
+	}
+
+	// A table that played hands refuses one once only a player is left
+	bob := player.NewPlayer("2", "Bob", 1000)
+	seats.AddPlayer(bob)
+	if err := game.StartHand(); err != nil {
+		t.Fatalf("Unexpected error starting a heads-up hand: %v", err)
+	}
+	game.EndHand()
+	bob.SitOut()
+	if err := game.StartHand(); err == nil {
+		t.Error("Expected error starting a hand with one player dealt in")
+	}
+}
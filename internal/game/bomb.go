@@ -0,0 +1,79 @@
+package game
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/prfc0/aksha/internal/player"
+)
+
+// BombPot configures the bomb pots a table plays: hands in which everyone
+// antes, there is no pre-flop betting and play starts on the flop.
+type BombPot struct {
+	Ante        int  // Chips every player dealt in puts in, instead of blinds and antes
+	DoubleBoard bool // Whether two boards are dealt, each winning half the pot
+	Every       int  // Hands between scheduled bomb pots; 0 plays them only when called
+	Votes       int  // Votes needed to call a bomb pot; 0 needs a majority of the table
+}
+
+// CallBombPot makes the next hand in a flop game a bomb pot, as the host may
+// at any time.
+func (g *Game) CallBombPot() error {
+	if g.BombPot == nil {
+		return fmt.Errorf("bomb pots are not enabled at this table")
+	}
+	g.bombCalled = true
+	g.bombVotes = nil
+	log.Println("A bomb pot is called for the next hand.")
+	return nil
+}
+
+// VoteBombPot records p's vote for a bomb pot and calls one once enough
+// players have voted. It reports whether the vote passed.
+func (g *Game) VoteBombPot(p *player.Player) (bool, error) {
+	if g.BombPot == nil {
+		return false, fmt.Errorf("bomb pots are not enabled at this table")
+	}
+	if g.positionOf(p) == -1 {
+		return false, fmt.Errorf("player %s is not at the table", p.Name)
+	}
+	if g.bombVotes == nil {
+		g.bombVotes = make(map[string]bool)
+	}
+	g.bombVotes[p.ID] = true
+	log.Printf("Player %s votes for a bomb pot.\n", p.Name)
+
+	needed := g.BombPot.Votes
+	if needed == 0 {
+		needed = len(g.Players)/2 + 1
+	}
+	if len(g.bombVotes) < needed {
+		return false, nil
+	}
+	return true, g.CallBombPot()
+}
+
+// bombPotDue reports whether the hand being started is a bomb pot, because
+// one was called or the schedule says so. Bomb pots are only played in
+// games with a board, and a called one waits for such a game.
+func (g *Game) bombPotDue() bool {
+	if g.BombPot == nil || g.stud() || g.boardSize() == 0 {
+		return false
+	}
+	return g.bombCalled || (g.BombPot.Every > 0 && g.handsSinceBomb+1 >= g.BombPot.Every)
+}
+
+// startBombPot collects the bomb pot ante from every player dealt in, deals
+// the hole cards and goes straight to the flop.
+func (g *Game) startBombPot() {
+	for _, player := range g.dealt {
+		g.postDeadChips(player, g.BombPot.Ante)
+	}
+	log.Printf("Bomb pot: posted antes of %d.\n", g.BombPot.Ante)
+
+	g.bombCalled = false
+	g.bombVotes = nil
+	g.handsSinceBomb = 0
+	g.DealCards()
+	g.DealStreet()
+}
@@ -0,0 +1,147 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/prfc0/aksha/internal/card"
+	"github.com/prfc0/aksha/internal/player"
+	"github.com/prfc0/aksha/internal/table"
+)
+
+func newBombPotGame(bombPot *BombPot) *Game {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+		player.NewPlayer("3", "Charlie", 1000),
+	}
+	game := NewGame(players, 5, 10)
+	game.BombPot = bombPot
+	return game
+}
+
+func TestCallBombPot(t *testing.T) {
+	game := newBombPotGame(&BombPot{Ante: 20})
+	if err := game.CallBombPot(); err != nil {
+		t.Fatalf("Unexpected error calling a bomb pot: %v", err)
+	}
+
+	game.StartHand()
+	if !game.BombPotHand || game.Pot.Chips != 60 || game.CurrentBet != 0 {
+		t.Errorf("Expected every player to ante 20 with no blinds, got pot %d and current bet %d", game.Pot.Chips, game.CurrentBet)
+	}
+	if game.BettingRound != 1 || len(game.CommunityCards) != 3 || len(game.Players[0].Hand) != 2 {
+		t.Errorf("Expected play to start on the flop, got betting round %d with %d community cards", game.BettingRound, len(game.CommunityCards))
+	}
+	game.EndHand()
+
+	game.StartHand()
+	if game.BombPotHand || game.Pot.Chips != 15 {
+		t.Errorf("Expected the following hand to be played with blinds, got pot %d", game.Pot.Chips)
+	}
+
+	if err := newBombPotGame(nil).CallBombPot(); err == nil {
+		t.Error("Expected error calling a bomb pot at a table without them")
+	}
+}
+
+func TestVoteBombPot(t *testing.T) {
+	game := newBombPotGame(&BombPot{Ante: 20})
+	alice, bob := game.Players[0], game.Players[1]
+
+	// Two of the three players make a majority, and voting twice counts once
+	for _, voter := range []*player.Player{alice, alice} {
+		if passed, err := game.VoteBombPot(voter); passed || err != nil {
+			t.Errorf("Expected one vote not to pass, got %v, %v", passed, err)
+		}
+	}
+	if passed, _ := game.VoteBombPot(bob); !passed {
+		t.Error("Expected a majority to call a bomb pot")
+	}
+	game.StartHand()
+	if !game.BombPotHand {
+		t.Error("Expected a bomb pot after the vote passed")
+	}
+
+	if _, err := game.VoteBombPot(player.NewPlayer("4", "Dave", 1000)); err == nil {
+		t.Error("Expected error voting from away from the table")
+	}
+}
+
+func TestScheduledBombPot(t *testing.T) {
+	game := newBombPotGame(&BombPot{Ante: 20, Every: 3})
+	for hand := 1; hand <= 6; hand++ {
+		game.StartHand()
+		if want := hand%3 == 0; game.BombPotHand != want {
+			t.Errorf("Hand %d: expected bomb pot %v, got %v", hand, want, game.BombPotHand)
+		}
+		game.EndHand()
+	}
+}
+
+func TestDoubleBoardBombPot(t *testing.T) {
+	game := newBombPotGame(&BombPot{Ante: 20, DoubleBoard: true})
+	game.CallBombPot()
+	game.StartHand()
+	game.DealStreet()
+	game.DealStreet()
+	if len(game.CommunityCards) != 5 || len(game.SecondBoard) != 5 {
+		t.Fatalf("Expected two full boards, got %d and %d cards", len(game.CommunityCards), len(game.SecondBoard))
+	}
+
+	// Alice makes a flush on the first board and Bob a straight on the second
+	game.CommunityCards = []*card.Card{
+		card.NewCard(card.Hearts, card.Two),
+		card.NewCard(card.Hearts, card.Seven),
+		card.NewCard(card.Hearts, card.Nine),
+		card.NewCard(card.Spades, card.King),
+		card.NewCard(card.Clubs, card.Four),
+	}
+	game.SecondBoard = []*card.Card{
+		card.NewCard(card.Spades, card.Six),
+		card.NewCard(card.Clubs, card.Seven),
+		card.NewCard(card.Diamonds, card.Eight),
+		card.NewCard(card.Spades, card.Two),
+		card.NewCard(card.Diamonds, card.Queen),
+	}
+	alice, bob, charlie := game.Players[0], game.Players[1], game.Players[2]
+	alice.Hand = []*card.Card{card.NewCard(card.Hearts, card.Ace), card.NewCard(card.Hearts, card.Three)}
+	bob.Hand = []*card.Card{card.NewCard(card.Clubs, card.Nine), card.NewCard(card.Clubs, card.Ten)}
+	charlie.Hand = []*card.Card{card.NewCard(card.Diamonds, card.Jack), card.NewCard(card.Clubs, card.Jack)}
+
+	game.EndHand()
+	if len(game.Runouts) != 2 || alice.Stack != 1010 || bob.Stack != 1010 || charlie.Stack != 980 {
+		t.Errorf("Expected each board to win half the pot, got stacks %d, %d and %d", alice.Stack, bob.Stack, charlie.Stack)
+	}
+	if err := game.RunOut(2); err == nil {
+		t.Error("Expected error running out a double-board hand")
+	}
+}
+
+func TestBlindsAfterBombPot(t *testing.T) {
+	for _, seated := range []bool{false, true} {
+		game := newBombPotGame(&BombPot{Ante: 20})
+		game.Players = append(game.Players, player.NewPlayer("4", "Dave", 1000))
+		if seated {
+			seats := table.NewTable(6)
+			for _, player := range game.Players {
+				seats.AddPlayer(player)
+			}
+			game.Table = seats
+		}
+
+		game.StartHand()
+		bigBlind := game.Players[game.BigBlindPosition()]
+		game.EndHand()
+		game.CallBombPot()
+		game.StartHand()
+		game.EndHand()
+
+		// The bomb pot posts no blinds, so the player after the last big
+		// blind is due it next
+		game.StartHand()
+		due := game.Players[(game.positionOf(bigBlind)+1)%len(game.Players)]
+		if got := game.Players[game.BigBlindPosition()]; got != due {
+			t.Errorf("At a table %v: expected %s in the big blind after the bomb pot, got %s", seated, due.Name, got.Name)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// ChipError reports that chips were created or destroyed during a hand.
+type ChipError struct {
+	Event    string // What happened just before the count
+	Expected int    // Chips on the table when the hand started
+	Actual   int    // Chips on the table after the event
+	Dump     string // State of the hand when the count was taken
+}
+
+func (e *ChipError) Error() string {
+	return fmt.Sprintf("chip count after %s is %d, expected %d\n%s", e.Event, e.Actual, e.Expected, e.Dump)
+}
+
+// ChipTotal returns the chips on the table: every player's stack plus the
+// pot. Bets are already in the pot, and chips added during a hand wait
+// outside it until the hand ends.
+func (g *Game) ChipTotal() int {
+	total := g.Pot.Chips
+	for _, player := range g.Players {
+		total += player.Stack
+	}
+	return total
+}
+
+// CheckChips returns a ChipError if the chips on the table differ from those
+// at the start of the hand.
+func (g *Game) CheckChips(event string) error {
+	if actual := g.ChipTotal(); actual != g.startingChips {
+		return &ChipError{Event: event, Expected: g.startingChips, Actual: actual, Dump: g.dump()}
+	}
+	return nil
+}
+
+// checkChips counts the chips after an event. A mismatch is logged, or
+// panics when StrictChips is set.
+func (g *Game) checkChips(event string) {
+	err := g.CheckChips(event)
+	if err == nil {
+		return
+	}
+	if g.StrictChips {
+		log.Panic(err)
+	}
+	log.Println(err)
+}
+
+// dump describes the hand for diagnosing a chip count mismatch.
+func (g *Game) dump() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Betting round %d, current bet %d, pot %d, board %v\n", g.BettingRound, g.CurrentBet, g.Pot.Chips, g.CommunityCards)
+	for _, player := range g.Players {
+		fmt.Fprintf(&b, "  %s: stack %d, bet %d, committed %d, %s\n", player.Name, player.Stack, player.Bet, player.Committed, player.Status())
+	}
+	return b.String()
+}
@@ -0,0 +1,52 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/prfc0/aksha/internal/action"
+)
+
+func TestChipsConserved(t *testing.T) {
+	game := newFourHandedGame()
+	game.Ante = 5
+	game.StrictChips = true
+
+	for hand := 0; hand < 3; hand++ {
+		game.StartHand()
+		for _, player := range game.ActionOrder() {
+			game.PerformAction(player, action.NewAction(action.Call, game.CurrentBet-player.Bet))
+		}
+		for _, cards := range []int{3, 1, 1} {
+			game.DealCommunityCards(cards)
+			game.PerformBettingRound()
+		}
+		game.EndHand()
+
+		if err := game.CheckChips("the hand"); err != nil {
+			t.Errorf("Unexpected chip count error: %v", err)
+		}
+		if game.ChipTotal() != 4000 {
+			t.Errorf("Expected 4000 chips on the table, got %d", game.ChipTotal())
+		}
+	}
+}
+
+func TestChipsCreated(t *testing.T) {
+	game := newFourHandedGame()
+	game.StartHand()
+	game.Players[0].Stack += 100
+
+	err := game.CheckChips("a bad top-up")
+	chipErr, ok := err.(*ChipError)
+	if !ok || chipErr.Expected != 4000 || chipErr.Actual != 4100 {
+		t.Fatalf("Expected a chip error of 4100 against 4000, got %v", err)
+	}
+
+	game.StrictChips = true
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a strict game to panic on a chip count mismatch")
+		}
+	}()
+	game.PerformAction(game.ActionOrder()[0], action.NewAction(action.Call, 20))
+}
@@ -0,0 +1,64 @@
+package game
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/prfc0/aksha/internal/card"
+	"github.com/prfc0/aksha/internal/player"
+)
+
+// NewFiveCardDrawGame initializes a fixed-limit Five-Card Draw game, betting
+// the big blind before the draw and twice it afterwards.
+func NewFiveCardDrawGame(players []*player.Player, smallBlind, bigBlind int) *Game {
+	return NewVariantGame(FiveCardDraw, players, smallBlind, bigBlind)
+}
+
+// NewTripleDrawGame initializes a fixed-limit 2-7 Triple Draw game, betting
+// the big blind on the first two rounds and twice it on the last two.
+func NewTripleDrawGame(players []*player.Player, smallBlind, bigBlind int) *Game {
+	return NewVariantGame(DeuceSevenTripleDraw, players, smallBlind, bigBlind)
+}
+
+// drawCards replaces the cards p discards with new ones from the deck.
+// Replacements are drawn before the discards join the discard pile, so a
+// player never draws their own discards back.
+func (g *Game) drawCards(p *player.Player, discards []*card.Card) error {
+	if streets := g.Variant.Streets(); g.BettingRound >= len(streets) || !streets[g.BettingRound].Draw {
+		return fmt.Errorf("player %s cannot draw: no draw is in progress", p.Name)
+	}
+	if !p.Active {
+		return fmt.Errorf("player %s cannot draw: not in the hand", p.Name)
+	}
+	if g.drawn[p.ID] {
+		return fmt.Errorf("player %s has already drawn", p.Name)
+	}
+
+	kept := make([]*card.Card, 0, len(p.Hand))
+	for _, holeCard := range p.Hand {
+		discarded := false
+		for _, discard := range discards {
+			if discard == holeCard {
+				discarded = true
+			}
+		}
+		if !discarded {
+			kept = append(kept, holeCard)
+		}
+	}
+	if len(kept)+len(discards) != len(p.Hand) {
+		return fmt.Errorf("player %s can only discard cards from their hand, once each", p.Name)
+	}
+	if len(discards) > len(g.Deck.Cards)+len(g.Deck.Discards) {
+		return fmt.Errorf("player %s cannot draw %d: not enough cards left", p.Name, len(discards))
+	}
+
+	p.Hand = kept
+	for range discards {
+		p.AddCard(g.Deck.Draw())
+	}
+	g.Deck.Discard(discards...)
+	g.drawn[p.ID] = true
+	log.Printf("Player %s draws %d.\n", p.Name, len(discards))
+	return nil
+}
@@ -0,0 +1,136 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/prfc0/aksha/internal/action"
+	"github.com/prfc0/aksha/internal/card"
+	"github.com/prfc0/aksha/internal/player"
+)
+
+func TestDraw(t *testing.T) {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+	}
+	game := NewTripleDrawGame(players, 10, 20)
+	game.StartHand()
+	alice, bob := game.Players[0], game.Players[1]
+
+	discards := []*card.Card{alice.Hand[0], alice.Hand[3]}
+	if err := game.PerformAction(alice, action.NewDraw(discards)); err == nil {
+		t.Error("Expected error drawing before the draw")
+	}
+
+	game.DealStreet()
+	if err := game.PerformAction(alice, action.NewDraw(discards)); err != nil {
+		t.Fatalf("Unexpected error drawing two: %v", err)
+	}
+	if len(alice.Hand) != 5 {
+		t.Errorf("Expected five cards after drawing, got %d", len(alice.Hand))
+	}
+	for _, holeCard := range alice.Hand {
+		if holeCard == discards[0] || holeCard == discards[1] {
+			t.Errorf("Expected %s to be discarded", holeCard)
+		}
+	}
+	if len(game.Deck.Discards) != 2 {
+		t.Errorf("Expected two discards, got %d", len(game.Deck.Discards))
+	}
+	if err := game.PerformAction(alice, action.NewDraw(nil)); err == nil {
+		t.Error("Expected error drawing twice in one draw")
+	}
+	if err := game.PerformAction(bob, action.NewDraw(discards)); err == nil {
+		t.Error("Expected error discarding another player's cards")
+	}
+	if err := game.PerformAction(bob, action.NewDraw([]*card.Card{bob.Hand[1], bob.Hand[1]})); err == nil {
+		t.Error("Expected error discarding the same card twice")
+	}
+	if err := game.PerformAction(bob, action.NewDraw(nil)); err != nil || len(bob.Hand) != 5 {
+		t.Errorf("Expected Bob to stand pat, got error: %v", err)
+	}
+}
+
+func TestDrawReshufflesDiscards(t *testing.T) {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+	}
+	game := NewTripleDrawGame(players, 10, 20)
+	game.StartHand()
+	alice, bob := game.Players[0], game.Players[1]
+
+	// One card is left in the deck when Bob draws two
+	game.Deck.Cards = game.Deck.Cards[:2]
+	game.DealStreet()
+	aliceDiscards := []*card.Card{alice.Hand[0]}
+	game.PerformAction(alice, action.NewDraw(aliceDiscards))
+	bobDiscards := []*card.Card{bob.Hand[0], bob.Hand[1]}
+	if err := game.PerformAction(bob, action.NewDraw(bobDiscards)); err != nil {
+		t.Fatalf("Unexpected error drawing from the discards: %v", err)
+	}
+
+	if bob.Hand[4] != aliceDiscards[0] {
+		t.Errorf("Expected Bob to draw Alice's discard, got %s", bob.Hand[4])
+	}
+	if len(game.Deck.Discards) != 2 {
+		t.Errorf("Expected Bob's own discards to stay out of the deck, got %d discards", len(game.Deck.Discards))
+	}
+	game.DealStreet()
+	if err := game.PerformAction(alice, action.NewDraw(alice.Hand[:3])); err == nil {
+		t.Error("Expected error drawing more cards than are left")
+	}
+}
+
+func TestTripleDrawShowdown(t *testing.T) {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+		player.NewPlayer("3", "Charlie", 1000),
+	}
+	game := NewTripleDrawGame(players, 10, 20)
+	// Alice's straight and Charlie's pair lose to Bob's eight low
+	players[0].Hand = []*card.Card{
+		card.NewCard(card.Spades, card.Six),
+		card.NewCard(card.Hearts, card.Five),
+		card.NewCard(card.Spades, card.Four),
+		card.NewCard(card.Clubs, card.Three),
+		card.NewCard(card.Spades, card.Two),
+	}
+	players[1].Hand = []*card.Card{
+		card.NewCard(card.Diamonds, card.Eight),
+		card.NewCard(card.Diamonds, card.Six),
+		card.NewCard(card.Hearts, card.Four),
+		card.NewCard(card.Diamonds, card.Three),
+		card.NewCard(card.Hearts, card.Two),
+	}
+	players[2].Hand = []*card.Card{
+		card.NewCard(card.Clubs, card.Two),
+		card.NewCard(card.Clubs, card.Five),
+		card.NewCard(card.Diamonds, card.Four),
+		card.NewCard(card.Hearts, card.Three),
+		card.NewCard(card.Diamonds, card.Two),
+	}
+
+	winners := game.DetermineWinner()
+	if len(winners) != 1 || winners[0].Name != "Bob" {
+		t.Errorf("Expected Bob to win, got %v", winners)
+	}
+}
+
+func TestFiveCardDrawBetSizes(t *testing.T) {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+	}
+	game := NewFiveCardDrawGame(players, 10, 20)
+	game.StartHand()
+	if len(game.Players[0].Hand) != 5 {
+		t.Errorf("Expected five hole cards, got %d", len(game.Players[0].Hand))
+	}
+
+	game.DealStreet()
+	if max := game.MaxAmount(game.Players[0]); max != 40 {
+		t.Errorf("Expected a big bet of 40 after the draw, got %d", max)
+	}
+}
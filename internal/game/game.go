@@ -1,7 +1,9 @@
 package game
 
 import (
+	"fmt"
 	"log"
+	"math/rand"
 
 	"github.com/prfc0/aksha/internal/action"
 	"github.com/prfc0/aksha/internal/card"
@@ -9,46 +11,142 @@ import (
 	"github.com/prfc0/aksha/internal/hand"
 	"github.com/prfc0/aksha/internal/player"
 	"github.com/prfc0/aksha/internal/pot"
+	"github.com/prfc0/aksha/internal/schedule"
+	"github.com/prfc0/aksha/internal/table"
 )
 
-// Game represents a single hand of Texas Hold'em poker.
+// Game represents a single hand of poker.
 type Game struct {
-	Players        []*player.Player // List of players in the game
-	Deck           *deck.Deck       // Deck of cards
-	Pot            *pot.Pot         // Total chips in the pot
-	CommunityCards []*card.Card     // Community cards on the table
-	CurrentBet     int              // Current bet amount
-	DealerPosition int              // Position of the dealer button
-	SmallBlind     int              // Small blind amount
-	BigBlind       int              // Big blind amount
-	BettingRound   int              // Current betting round (0: pre-flop, 1: flop, 2: turn, 3: river)
-}
-
-// NewGame initializes a new game with the given players and blinds.
+	Variant        Variant                 // Form of poker played
+	HoleCards      int                     // Hole cards dealt to each player; 0 deals the variant's usual number
+	Limit          BettingLimit            // Cap on bets and raises
+	SmallBet       int                     // Fixed-limit bet on the first two betting rounds
+	BigBet         int                     // Fixed-limit bet on later betting rounds
+	BringIn        int                     // Forced bet of the lowest up card in stud
+	MaxBets        int                     // Bets and raises allowed on each fixed-limit betting round, counting the big blind; 0 allows four
+	Players        []*player.Player        // List of players in the game
+	Deck           *deck.Deck              // Deck of cards
+	Pot            *pot.Pot                // Total chips in the pot
+	CommunityCards []*card.Card            // Community cards on the table
+	CurrentBet     int                     // Current bet amount
+	DealerPosition int                     // Position of the dealer button
+	SmallBlind     int                     // Small blind amount
+	BigBlind       int                     // Big blind amount
+	Ante           int                     // Ante posted by every player dealt in
+	BigBlindAnte   int                     // Single ante posted by the big blind for the whole table
+	ButtonAnte     int                     // Ante posted by the button in place of blinds; action then starts left of the button
+	Straddle       StraddleType            // Who may straddle before the deal
+	StraddleForced bool                    // Whether the first straddle is mandatory
+	MaxStraddles   int                     // Number of straddles allowed including re-straddles; 0 allows one
+	Straddles      []*player.Player        // Players who straddled this hand, in order
+	BettingRound   int                     // Current betting round (0: pre-flop, 1: flop, 2: turn, 3: river)
+	LastAggressor  *player.Player          // Last player to bet or raise on the current betting round
+	Shown          map[string][]*card.Card // Hole cards each player has shown, by player ID
+	Muck           MuckFunc                // Asked whether a beaten hand is mucked at showdown; nil shows every hand
+	Runouts        []*Runout               // Boards dealt when the hand is run out more than once or played on two boards
+	History        []Move                  // Actions taken in the current hand, in order
+	BombPot        *BombPot                // Bomb pot settings; nil never plays bomb pots
+	BombPotHand    bool                    // Whether the current hand is a bomb pot
+	SecondBoard    []*card.Card            // Second board of a double-board bomb pot
+	Table          *table.Table            // Table whose seats place the button and blinds; nil lets the game place them
+	Schedule       *schedule.Schedule      // Blind levels applied between hands; nil keeps the blinds fixed
+	Rotation       *Rotation               // Games the table plays in turn; nil plays Variant every hand
+	StrictChips    bool                    // Whether chips created or destroyed during a hand panic rather than being logged
+
+	dealt            []*player.Player        // Players dealt into the current hand
+	previousBigBlind *player.Player          // Player who posted the big blind in the previous hand
+	startingChips    int                     // Chips on the table when the hand started
+	bets             int                     // Bets and raises made on the current betting round, counting the big blind
+	lastRaise        int                     // Size of the largest bet or raise on the current betting round, counting the big blind
+	bringIn          *player.Player          // Player who posted the stud bring-in
+	upCards          map[string][]*card.Card // Face-up stud cards, by player ID
+	drawn            map[string]bool         // Players who have drawn in the current draw, by player ID
+	doubleBoard      bool                    // Whether the current hand is dealt on two boards
+	bombCalled       bool                    // Whether the host or a vote called a bomb pot for the next hand
+	bombVotes        map[string]bool         // Players who voted for a bomb pot, by player ID
+	handsSinceBomb   int                     // Hands played since the last bomb pot
+	rng              *rand.Rand              // Source of deck shuffles; nil shuffles from the time
+}
+
+// NewGame initializes a new game of Texas Hold'em with the given players
+// and blinds.
 func NewGame(players []*player.Player, smallBlind, bigBlind int) *Game {
-	deck := deck.NewDeck()
-	deck.Shuffle()
+	return NewVariantGame(Holdem, players, smallBlind, bigBlind)
+}
 
+// newGame initializes a game without a variant or deck.
+func newGame(players []*player.Player, smallBlind, bigBlind int) *Game {
 	return &Game{
 		Players:        players,
-		Deck:           deck,
 		Pot:            pot.NewPot(),
 		CommunityCards: make([]*card.Card, 0),
 		CurrentBet:     0,
-		DealerPosition: len(players) - 1,
+		DealerPosition: 0,
 		SmallBlind:     smallBlind,
 		BigBlind:       bigBlind,
 		BettingRound:   0,
+		Shown:          make(map[string][]*card.Card),
+		upCards:        make(map[string][]*card.Card),
+		dealt:          players,
 	}
 }
 
-// StartHand starts a new hand of poker.
-func (g *Game) StartHand() {
-	log.Println("Starting a new hand of Texas Hold'em.")
+// Seed makes the game's shuffles repeatable from seed, starting with a
+// freshly shuffled deck.
+func (g *Game) Seed(seed int64) {
+	g.rng = rand.New(rand.NewSource(seed))
+	g.newDeck()
+}
 
-	// Reset player hands and status
+// newDeck replaces the deck with a new, shuffled one for the variant.
+func (g *Game) newDeck() {
+	g.Deck = g.Variant.NewDeck()
+	g.Deck.Rand = g.rng
+	g.Deck.Shuffle()
+}
+
+// StartHand starts a new hand of poker. It refuses to start one, without
+// taking any chips, if fewer than two players can be dealt in, the deck
+// cannot deal every player their hole cards and the board, or the big
+// blind cannot be placed.
+func (g *Game) StartHand() error {
+	log.Printf("Starting a new hand of %s.\n", g.Variant)
+	if g.Schedule != nil {
+		g.applySchedule()
+	}
+
+	// Reset player hands and status; players without chips or sitting out
+	// are not dealt in. Nobody posts blinds in a bomb pot, so they stay put
+	// for the next hand
+	bombPot := g.bombPotDue()
+	if g.Table != nil {
+		g.Players = g.Table.Players
+		if bombPot {
+			g.Table.HoldButton()
+		} else {
+			g.Table.RotateDealer()
+		}
+	}
 	for _, player := range g.Players {
 		player.ResetHand()
+		if player.Stack == 0 || player.SittingOut {
+			player.Active = false
+		}
+	}
+	g.dealt = g.activePlayers()
+	if len(g.dealt) < 2 {
+		return fmt.Errorf("cannot start a hand with %d players dealt in", len(g.dealt))
+	}
+	if g.HoleCards > 0 {
+		if deckSize := len(g.Variant.NewDeck().Cards); g.HoleCards*len(g.dealt)+g.boardSize() > deckSize {
+			return fmt.Errorf("cannot deal %d hole cards to %d players and a board from %d cards", g.HoleCards, len(g.dealt), deckSize)
+		}
+	}
+	if g.Table == nil {
+		if !g.Players[g.DealerPosition].Active {
+			g.moveButton()
+		}
+		g.adjustHeadsUpButton()
 	}
 
 	// Reset community cards and pot
@@ -58,34 +156,145 @@ func (g *Game) StartHand() {
 	g.Pot.Chips = 0
 	log.Println("Resetting Current Bet to 0.")
 	g.CurrentBet = 0
-}
+	g.BettingRound = 0
+	g.LastAggressor = nil
+	g.Shown = make(map[string][]*card.Card)
+	g.Runouts = nil
+	g.History = nil
+	g.Straddles = nil
+	g.bets = 0
+	g.lastRaise = 0
+	g.bringIn = nil
+	g.upCards = make(map[string][]*card.Card)
+	g.drawn = make(map[string]bool)
+	g.BombPotHand = bombPot
+	g.doubleBoard = g.BombPotHand && g.BombPot.DoubleBoard
+	g.SecondBoard = nil
+	g.startingChips = g.ChipTotal()
 
-// postBlinds posts the small and big blinds.
-func (g *Game) PostBlinds() {
-	smallBlindPlayer := g.Players[(g.DealerPosition+1)%len(g.Players)]
-	bigBlindPlayer := g.Players[(g.DealerPosition+2)%len(g.Players)]
+	if g.BombPotHand {
+		g.startBombPot()
+		g.checkChips("posting the bomb pot")
+		return nil
+	}
+	if !g.stud() && g.ButtonAnte == 0 && g.BigBlindPosition() == -1 {
+		return fmt.Errorf("cannot start a hand: no player dealt in is in the big blind")
+	}
+	g.handsSinceBomb++
 
-	smallBlindAction := action.NewAction(action.Bet, g.SmallBlind)
-	smallBlindPlayer.PerformAction(smallBlindAction, g.SmallBlind)
+	// Stud is dealt before the bring-in, which depends on the up cards
+	g.PostAntes()
+	if !g.stud() {
+		if g.ButtonAnte > 0 {
+			g.postButtonAnte()
+		} else if err := g.PostBlinds(); err != nil {
+			return err
+		}
+	}
+	g.DealCards()
+	if g.stud() {
+		g.postBringIn()
+	}
+	g.checkChips("posting blinds")
+	return nil
+}
 
-	bigBlindAction := action.NewAction(action.Bet, g.BigBlind)
-	bigBlindPlayer.PerformAction(bigBlindAction, g.BigBlind)
+// PostBlinds posts the small and big blinds. It returns an error, posting
+// nothing, if no player dealt in is in the big blind.
+func (g *Game) PostBlinds() error {
+	bigBlindPosition := g.BigBlindPosition()
+	if bigBlindPosition == -1 {
+		return fmt.Errorf("no player dealt in is in the big blind")
+	}
+	bigBlindPlayer := g.Players[bigBlindPosition]
+	g.previousBigBlind = bigBlindPlayer
 
-	g.Pot.AddChips(g.SmallBlind + g.BigBlind)
+	if g.SmallBlindPosition() != -1 {
+		smallBlindPlayer := g.Players[g.SmallBlindPosition()]
+		g.postBlind(smallBlindPlayer, g.SmallBlind)
+		log.Printf("Posted small blind: %s.\n", smallBlindPlayer.Name)
+	} else {
+		log.Println("Dead small blind.")
+	}
+	g.postBlind(bigBlindPlayer, g.BigBlind)
 	g.CurrentBet = g.BigBlind
+	if g.BigBlind > 0 {
+		g.bets = 1
+	}
+	g.lastRaise = g.BigBlind
+	g.postBigBlindAnte()
+	log.Printf("Posted big blind: %s.\n", bigBlindPlayer.Name)
+
+	g.postMissedBlinds()
 
-	log.Printf("Posted blinds: %s (small blind) and %s (big blind).\n", smallBlindPlayer.Name, bigBlindPlayer.Name)
+	if g.StraddleForced {
+		if next := g.NextStraddler(); next != -1 {
+			if err := g.PostStraddle(g.Players[next]); err != nil {
+				log.Printf("Mandatory straddle not posted: %v\n", err)
+			}
+		}
+	}
+	return nil
 }
 
-// DealCards deals two cards to each player.
+// DealCards deals the variant's opening street to each player dealt into
+// the hand: their hole cards, and in stud their first up card.
 func (g *Game) DealCards() {
-	for i := 0; i < 2; i++ {
-		for _, player := range g.Players {
+	street := g.Variant.Streets()[0]
+	if g.HoleCards > 0 {
+		street.Down = g.HoleCards
+	}
+	g.dealPlayerCards(g.dealt, street)
+	log.Println("Dealt cards to all players.")
+}
+
+// DealStreet starts the next betting round and deals the variant's street
+// for it: community cards, cards to each player still in the hand, or a
+// draw, in which the players each draw in action order with a Draw action
+// before betting. If the deck cannot give every player their cards, as can
+// happen on seventh street in stud, one community card is dealt face up for
+// all of them to share instead.
+func (g *Game) DealStreet() {
+	streets := g.Variant.Streets()
+	if g.BettingRound+1 >= len(streets) {
+		log.Println("No streets left to deal.")
+		return
+	}
+	street := streets[g.BettingRound+1]
+	if street.Board > 0 {
+		g.DealCommunityCards(street.Board)
+		return
+	}
+
+	g.startBettingRound()
+	if street.Draw {
+		g.drawn = make(map[string]bool)
+		log.Printf("Starting a draw on betting round %d.\n", g.BettingRound)
+		return
+	}
+	players := g.activePlayers()
+	if len(g.Deck.Cards) < (street.Down+street.Up)*len(players) {
+		card := g.Deck.Draw()
+		g.CommunityCards = append(g.CommunityCards, card)
+		log.Printf("Dealt community card: %s\n", card.String())
+		return
+	}
+	g.dealPlayerCards(players, street)
+	log.Printf("Dealt cards for betting round %d.\n", g.BettingRound)
+}
+
+// dealPlayerCards deals each of players a street's down cards and then its
+// up cards, one card at a time around the table.
+func (g *Game) dealPlayerCards(players []*player.Player, street Street) {
+	for i := 0; i < street.Down+street.Up; i++ {
+		for _, player := range players {
 			card := g.Deck.Draw()
 			player.AddCard(card)
+			if i >= street.Down {
+				g.upCards[player.ID] = append(g.upCards[player.ID], card)
+			}
 		}
 	}
-	log.Println("Dealt cards to all players.")
 }
 
 /*
@@ -114,25 +323,93 @@ func (g *Game) ManageBettingRounds() {
 }
 */
 
-// dealCommunityCards deals the specified number of community cards.
+// DealCommunityCards deals the specified number of community cards, to
+// each board of a double-board hand, and starts the next betting round.
 func (g *Game) DealCommunityCards(numCards int) {
+	g.startBettingRound()
 	for i := 0; i < numCards; i++ {
 		card := g.Deck.Draw()
 		g.CommunityCards = append(g.CommunityCards, card)
 		log.Printf("Dealt community card: %s\n", card.String())
 	}
+	if g.doubleBoard {
+		for i := 0; i < numCards; i++ {
+			card := g.Deck.Draw()
+			g.SecondBoard = append(g.SecondBoard, card)
+			log.Printf("Dealt second board card: %s\n", card.String())
+		}
+	}
+}
+
+// startBettingRound clears the bets of the previous betting round.
+func (g *Game) startBettingRound() {
+	g.BettingRound++
+	g.CurrentBet = 0
+	g.LastAggressor = nil
+	g.bets = 0
+	g.lastRaise = 0
+	for _, player := range g.Players {
+		player.StartBettingRound()
+	}
+}
+
+// PerformAction applies a player's action to the hand. The action amount is
+// the number of chips the player puts in with it.
+func (g *Game) PerformAction(p *player.Player, a *action.Action) error {
+	if a.Type == action.Fold {
+		p.Fold()
+		g.record(p, a)
+		return nil
+	}
+	if a.Type == action.Draw {
+		if err := g.drawCards(p, a.Cards); err != nil {
+			return err
+		}
+		g.record(p, a)
+		return nil
+	}
+
+	if err := g.validateAmount(p, a); err != nil {
+		return err
+	}
+	if err := p.PerformAction(a, g.CurrentBet); err != nil {
+		return err
+	}
+	g.Pot.AddChips(a.Amount)
+	g.record(p, a)
+
+	if p.Bet > g.CurrentBet {
+		// A short all-in raise does not lower the next minimum raise
+		g.lastRaise = max(g.lastRaise, p.Bet-g.CurrentBet)
+		g.CurrentBet = p.Bet
+		g.LastAggressor = p
+		g.bets++
+	}
+	g.checkChips(fmt.Sprintf("%s %s %d", p.Name, a.Type, a.Amount))
+	return nil
 }
 
-// performBettingRound performs a single betting round.
+// Move is an action a player took in a hand.
+type Move struct {
+	PlayerID     string         // Player who took the action
+	BettingRound int            // Betting round the action was taken on
+	Action       *action.Action // Action taken
+}
+
+// record adds p's action to the hand's history.
+func (g *Game) record(p *player.Player, a *action.Action) {
+	g.History = append(g.History, Move{PlayerID: p.ID, BettingRound: g.BettingRound, Action: a})
+}
+
+// PerformBettingRound performs a single betting round.
 func (g *Game) PerformBettingRound() {
 	// TODO: Implement betting logic (e.g., players take turns to act)
 	log.Println("Performing betting round...")
-	for _, player := range g.Players {
-		if player.Active {
+	for _, player := range g.ActionOrder() {
+		if player.Active && !player.AllIn {
 			// Simulate a player action (e.g., Call the current bet)
-			action := action.NewAction(action.Call, g.CurrentBet)
-			err := player.PerformAction(action, g.CurrentBet)
-			g.Pot.Chips += g.CurrentBet
+			action := action.NewAction(action.Call, g.toCall(player))
+			err := g.PerformAction(player, action)
 			if err != nil {
 				log.Printf("Player %s could not perform action: %v\n", player.Name, err)
 			}
@@ -143,13 +420,13 @@ func (g *Game) PerformBettingRound() {
 // DetermineWinner determines the winner(s) of the hand.
 func (g *Game) DetermineWinner() []*player.Player {
 	// Evaluate each player's best 5-card hand
-	bestHands := make([]*hand.Hand, 0)
+	evaluator := g.evaluator()
+	contenders := make([]*player.Player, 0)
+	bestHands := make([]hand.Ranked, 0)
 	for _, player := range g.Players {
 		if player.Active {
-			// Combine player's hole cards with community cards
-			cards := append(player.Hand, g.CommunityCards...)
-			// Evaluate the best 5-card hand
-			bestHand := hand.NewHand(cards)
+			bestHand := evaluator.Evaluate(player.Hand, g.CommunityCards)
+			contenders = append(contenders, player)
 			bestHands = append(bestHands, bestHand)
 			log.Printf("Player %s has hand: %v\n", player.Name, bestHand)
 		}
@@ -159,17 +436,17 @@ func (g *Game) DetermineWinner() []*player.Player {
 	winners := make([]*player.Player, 0)
 	if len(bestHands) > 0 {
 		strongestHand := bestHands[0]
-		winners = append(winners, g.Players[0])
+		winners = append(winners, contenders[0])
 
 		for i := 1; i < len(bestHands); i++ {
-			comparison := bestHands[i].Compare(strongestHand)
+			comparison := evaluator.Compare(bestHands[i], strongestHand)
 			if comparison == 1 {
 				// New strongest hand
 				strongestHand = bestHands[i]
-				winners = []*player.Player{g.Players[i]}
+				winners = []*player.Player{contenders[i]}
 			} else if comparison == 0 {
 				// Tie
-				winners = append(winners, g.Players[i])
+				winners = append(winners, contenders[i])
 			}
 		}
 	}
@@ -177,20 +454,49 @@ func (g *Game) DetermineWinner() []*player.Player {
 	return winners
 }
 
+// activePlayers returns the players still in the hand.
+func (g *Game) activePlayers() []*player.Player {
+	active := make([]*player.Player, 0)
+	for _, player := range g.Players {
+		if player.Active {
+			active = append(active, player)
+		}
+	}
+	return active
+}
+
 // EndHand ends the current hand and resets the game state.
 func (g *Game) EndHand() {
-	// Determine the winner(s)
-	winners := g.DetermineWinner()
-
-	// Distribute the pot to the winner(s)
-	potPerWinner := g.Pot.Chips / len(winners)
-	for _, winner := range winners {
-		winner.Stack += potPerWinner
-		log.Printf("Player %s wins %d chips!\n", winner.Name, potPerWinner)
+	// A pot that was run out has already been awarded board by board
+	if len(g.Runouts) == 0 {
+		if len(g.activePlayers()) > 1 && g.doubleBoard {
+			// Each board of a double-board hand wins half of every pot
+			g.splitBoards([][]*card.Card{g.CommunityCards, g.SecondBoard})
+		} else {
+			// Award the main pot and side pots; a pot nobody called is won
+			// without a showdown
+			g.awardPots()
+		}
 	}
+	g.checkChips("awarding the pot")
 
-	// Reset game state for the next hand
-	g.DealerPosition = (g.DealerPosition + 1) % len(g.Players)
+	// Reset game state for the next hand; the blinds nobody posted in a
+	// bomb pot fall on the same players next hand
+	if g.Table == nil {
+		if !g.BombPotHand {
+			g.moveButton()
+		}
+	} else {
+		g.Table.EndHand()
+	}
+	if g.Schedule != nil {
+		g.Schedule.HandPlayed()
+	}
+	if g.Rotation != nil && g.Rotation.HandPlayed(len(g.dealt)) {
+		g.applyRotation()
+	} else {
+		g.newDeck()
+	}
 	g.BettingRound = 0
 	log.Println("Hand ended. Ready for the next hand.")
 }
@@ -3,6 +3,7 @@ package game
 import (
 	"testing"
 
+	"github.com/prfc0/aksha/internal/action"
 	"github.com/prfc0/aksha/internal/card"
 	"github.com/prfc0/aksha/internal/player"
 )
@@ -47,6 +48,78 @@ func TestDealCards(t *testing.T) {
 	}
 }
 
+func TestPerformAction(t *testing.T) {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 100),
+	}
+	game := NewGame(players, 10, 20)
+	game.Ante = 5
+	game.StartHand()
+
+	// Heads-up Alice is the small blind and raises to 60
+	alice, bob := players[0], players[1]
+	game.PerformAction(alice, action.NewAction(action.Raise, 50))
+	if alice.Bet != 60 || alice.Committed != 65 || game.CurrentBet != 60 {
+		t.Errorf("Expected Alice to bet 60 with 65 committed, got bet %d, committed %d", alice.Bet, alice.Committed)
+	}
+
+	game.PerformAction(bob, action.NewAction(action.Call, 40))
+	game.DealCommunityCards(3)
+	if alice.Bet != 0 || alice.Committed != 65 {
+		t.Errorf("Expected Alice's bet to clear on the flop with 65 still committed, got bet %d, committed %d", alice.Bet, alice.Committed)
+	}
+
+	game.PerformAction(bob, action.NewAction(action.Bet, 35))
+	if !bob.AllIn || bob.Committed != 100 || bob.Status() != player.StatusAllIn {
+		t.Errorf("Expected Bob all-in for 100, got committed %d, status %s", bob.Committed, bob.Status())
+	}
+	if order := game.ActionOrder(); len(order) != 1 || order[0] != alice {
+		t.Errorf("Expected only Alice left to act, got %v", order)
+	}
+}
+
+func TestPerformActionAmounts(t *testing.T) {
+	tests := []struct {
+		name   string
+		stack  int
+		action *action.Action
+		valid  bool
+	}{
+		{"call", 1000, action.NewAction(action.Call, 20), true},
+		{"short call", 1000, action.NewAction(action.Call, 1), false},
+		{"call for too much", 1000, action.NewAction(action.Call, 40), false},
+		{"minimum raise", 1000, action.NewAction(action.Raise, 40), true},
+		{"raise below the minimum", 1000, action.NewAction(action.Raise, 31), false},
+		{"short all-in raise", 50, action.NewAction(action.Raise, 30), true},
+		{"short all-in call", 30, action.NewAction(action.Call, 10), true},
+	}
+	for _, tt := range tests {
+		players := []*player.Player{
+			player.NewPlayer("1", "Alice", tt.stack),
+			player.NewPlayer("2", "Bob", 1000),
+		}
+		game := NewGame(players, 10, 20)
+		game.StartHand()
+		alice, bob := players[0], players[1]
+		game.PerformAction(alice, action.NewAction(action.Call, 10))
+		game.PerformAction(bob, action.NewAction(action.Call, 0))
+		game.DealCommunityCards(3)
+
+		// Bob bets 20 on the flop
+		if err := game.PerformAction(bob, action.NewAction(action.Bet, 20)); err != nil {
+			t.Fatalf("%s: unexpected error betting: %v", tt.name, err)
+		}
+		err := game.PerformAction(alice, tt.action)
+		if tt.valid && err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
+
 func TestEndHand(t *testing.T) {
 	players := []*player.Player{
 		player.NewPlayer("1", "Alice", 1000),
@@ -74,7 +147,7 @@ func TestDetermineWinner(t *testing.T) {
 		card.NewCard(card.Spades, card.King),
 		card.NewCard(card.Spades, card.Queen),
 		card.NewCard(card.Spades, card.Jack),
-		card.NewCard(card.Spades, card.Ten),
+		card.NewCard(card.Hearts, card.Ten),
 	}
 	players[0].AddCard(card.NewCard(card.Spades, card.Nine)) // Alice has a flush
 	players[1].AddCard(card.NewCard(card.Hearts, card.Nine)) // Bob has a straight
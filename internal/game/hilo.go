@@ -0,0 +1,32 @@
+package game
+
+import (
+	"github.com/prfc0/aksha/internal/card"
+	"github.com/prfc0/aksha/internal/player"
+)
+
+// LowWinners returns the players holding the best qualifying low, in
+// showdown order. It is empty when no low qualifies or the game does not
+// split pots.
+func (g *Game) LowWinners() []*player.Player {
+	return g.lowWinnersAmong(g.CommunityCards, g.ShowdownOrder())
+}
+
+// lowWinnersAmong returns the contenders holding the best qualifying low on
+// the given board, in the order given.
+func (g *Game) lowWinnersAmong(board []*card.Card, contenders []*player.Player) []*player.Player {
+	evaluator := g.lowEvaluator()
+	if evaluator == nil {
+		return make([]*player.Player, 0)
+	}
+	return winnersAmong(evaluator, board, contenders)
+}
+
+func contains(players []*player.Player, p *player.Player) bool {
+	for _, player := range players {
+		if player == p {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package game
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/prfc0/aksha/internal/player"
+)
+
+// MixedGame is one game of a mixed-game rotation and the stakes it is
+// played at.
+type MixedGame struct {
+	Variant    Variant      // Game played
+	Limit      BettingLimit // Betting limit the game is played at in the rotation
+	SmallBlind int          // Small blind in flop and draw games
+	BigBlind   int          // Big blind in flop and draw games
+	Ante       int          // Ante in stud games
+	BringIn    int          // Bring-in in stud games
+	SmallBet   int          // Fixed-limit bet on the early betting rounds
+	BigBet     int          // Fixed-limit bet on the later betting rounds
+}
+
+func (m MixedGame) String() string {
+	limit := map[BettingLimit]string{NoLimit: "No-Limit", PotLimit: "Pot-Limit", FixedLimit: "Limit"}[m.Limit]
+	return fmt.Sprintf("%s %s", limit, m.Variant)
+}
+
+// Rotation is the list of games a mixed-game table plays in turn.
+type Rotation struct {
+	Games   []MixedGame // Games in the order they are played
+	Hands   int         // Hands of each game before moving on; 0 moves on after each orbit of the button
+	Current int         // Index of the game being played
+
+	handsPlayed int // Hands played of the current game
+	orbit       int // Players dealt into the first hand of the current game
+}
+
+// NewRotation creates a rotation through games, changing game every hands
+// hands or, if hands is 0, after each orbit.
+func NewRotation(games []MixedGame, hands int) (*Rotation, error) {
+	if len(games) == 0 {
+		return nil, fmt.Errorf("a rotation needs at least one game")
+	}
+	if hands < 0 {
+		return nil, fmt.Errorf("cannot play %d hands of each game", hands)
+	}
+	return &Rotation{Games: games, Hands: hands}, nil
+}
+
+// Game returns the game being played.
+func (r *Rotation) Game() MixedGame {
+	return r.Games[r.Current]
+}
+
+// Next returns the game that comes after the current one.
+func (r *Rotation) Next() MixedGame {
+	return r.Games[(r.Current+1)%len(r.Games)]
+}
+
+// HandsRemaining returns the number of hands left of the current game. In
+// a rotation by orbit it is not known before the game's first hand, when it
+// returns 0.
+func (r *Rotation) HandsRemaining() int {
+	hands := r.Hands
+	if hands == 0 {
+		hands = r.orbit
+	}
+	if hands < r.handsPlayed {
+		return 0
+	}
+	return hands - r.handsPlayed
+}
+
+// HandPlayed records a hand of the current game dealt to the given number
+// of players and moves on to the next game when its hands are up. It
+// reports whether the game changed.
+func (r *Rotation) HandPlayed(players int) bool {
+	if r.handsPlayed == 0 {
+		r.orbit = players
+	}
+	r.handsPlayed++
+	if r.HandsRemaining() > 0 {
+		return false
+	}
+	r.Current = (r.Current + 1) % len(r.Games)
+	r.handsPlayed = 0
+	r.orbit = 0
+	return true
+}
+
+// HORSE returns the games of HORSE at fixed-limit: Hold'em, Omaha Hi/Lo,
+// Razz, Seven-Card Stud and Seven-Card Stud Hi/Lo.
+func HORSE(smallBet, bigBet int) []MixedGame {
+	return []MixedGame{
+		flopGame(Holdem, FixedLimit, smallBet, bigBet),
+		flopGame(OmahaHiLo, FixedLimit, smallBet, bigBet),
+		studGame(Razz, smallBet, bigBet),
+		studGame(SevenCardStud, smallBet, bigBet),
+		studGame(SevenCardStudHiLo, smallBet, bigBet),
+	}
+}
+
+// EightGame returns the games of the eight-game mix: 2-7 Triple Draw, the
+// HORSE games, No-Limit Hold'em and Pot-Limit Omaha.
+func EightGame(smallBet, bigBet int) []MixedGame {
+	games := []MixedGame{flopGame(DeuceSevenTripleDraw, FixedLimit, smallBet, bigBet)}
+	games = append(games, HORSE(smallBet, bigBet)...)
+	return append(games,
+		flopGame(Holdem, NoLimit, smallBet, bigBet),
+		flopGame(Omaha, PotLimit, smallBet, bigBet),
+	)
+}
+
+// flopGame returns a blinds game with the small bet as the big blind.
+func flopGame(v Variant, limit BettingLimit, smallBet, bigBet int) MixedGame {
+	return MixedGame{Variant: v, Limit: limit, SmallBlind: smallBet / 2, BigBlind: smallBet, SmallBet: smallBet, BigBet: bigBet}
+}
+
+// studGame returns a stud game with an ante of a fifth of the small bet and
+// a bring-in of a quarter of it.
+func studGame(v Variant, smallBet, bigBet int) MixedGame {
+	return MixedGame{Variant: v, Limit: FixedLimit, Ante: max(smallBet/5, 1), BringIn: max(smallBet/4, 1), SmallBet: smallBet, BigBet: bigBet}
+}
+
+// NewMixedGame initializes a game that plays the games of rotation in turn.
+func NewMixedGame(players []*player.Player, rotation *Rotation) *Game {
+	g := NewVariantGame(rotation.Game().Variant, players, 0, 0)
+	g.Rotation = rotation
+	g.applyRotation()
+	return g
+}
+
+// applyRotation switches to the rotation's current game, with its betting
+// limit, stakes and deck.
+func (g *Game) applyRotation() {
+	current := g.Rotation.Game()
+	g.Variant = current.Variant
+	g.Limit = current.Limit
+	g.SmallBlind = current.SmallBlind
+	g.BigBlind = current.BigBlind
+	g.Ante = current.Ante
+	g.BringIn = current.BringIn
+	g.SmallBet = current.SmallBet
+	g.BigBet = current.BigBet
+	g.HoleCards = 0
+	g.newDeck()
+	log.Printf("Now playing %s; %s is next.\n", current, g.Rotation.Next())
+}
@@ -0,0 +1,68 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/prfc0/aksha/internal/player"
+)
+
+func TestMixedGameRotation(t *testing.T) {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+		player.NewPlayer("3", "Charlie", 1000),
+	}
+	rotation, err := NewRotation(HORSE(20, 40), 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	game := NewMixedGame(players, rotation)
+	if game.Variant != Holdem || game.Limit != FixedLimit || game.BigBlind != 20 {
+		t.Fatalf("Expected Limit Hold'em with a big blind of 20, got %s", rotation.Game())
+	}
+
+	tests := []struct {
+		variant   Variant
+		remaining int
+		next      string
+	}{
+		{Holdem, 1, "Limit Omaha Hi/Lo"},
+		{OmahaHiLo, 2, "Limit Razz"},
+		{OmahaHiLo, 1, "Limit Razz"},
+		{Razz, 2, "Limit Seven-Card Stud"},
+	}
+	for i, test := range tests {
+		game.StartHand()
+		game.EndHand()
+		if game.Variant != test.variant || rotation.HandsRemaining() != test.remaining || rotation.Next().String() != test.next {
+			t.Errorf("Hand %d: expected %s with %d hands left and %s next, got %s with %d left and %s next",
+				i+1, test.variant, test.remaining, test.next, game.Variant, rotation.HandsRemaining(), rotation.Next())
+		}
+	}
+
+	// Razz is dealt with antes and a bring-in instead of blinds
+	game.StartHand()
+	if game.BigBlind != 0 || game.Ante != 4 || game.bringIn == nil || len(players[0].Hand) != 3 {
+		t.Errorf("Expected Razz stakes and a stud deal, got big blind %d and ante %d", game.BigBlind, game.Ante)
+	}
+}
+
+func TestRotationByOrbit(t *testing.T) {
+	rotation, _ := NewRotation(EightGame(20, 40), 0)
+	if len(rotation.Games) != 8 || rotation.Game().Variant != DeuceSevenTripleDraw {
+		t.Fatalf("Expected eight games starting with 2-7 Triple Draw, got %v", rotation.Games)
+	}
+
+	for hand := 1; hand < 4; hand++ {
+		if rotation.HandPlayed(4) {
+			t.Errorf("Expected the game not to change after hand %d of a four-handed orbit", hand)
+		}
+	}
+	if !rotation.HandPlayed(3) || rotation.Game().Variant != Holdem {
+		t.Errorf("Expected Limit Hold'em after a full orbit, got %s", rotation.Game())
+	}
+
+	if _, err := NewRotation(nil, 0); err == nil {
+		t.Error("Expected error creating a rotation without games")
+	}
+}
@@ -0,0 +1,163 @@
+package game
+
+import (
+	"github.com/prfc0/aksha/internal/player"
+)
+
+// HeadsUp reports whether only two players are dealt into the hand.
+func (g *Game) HeadsUp() bool {
+	return len(g.dealt) == 2
+}
+
+// ButtonPosition returns the position of the player on the button, or -1 if
+// the table has a dead button.
+func (g *Game) ButtonPosition() int {
+	if g.Table != nil {
+		return g.dealtInAt(g.Table.DealerPosition)
+	}
+	return g.DealerPosition
+}
+
+// SmallBlindPosition returns the position of the small blind, or -1 if the
+// table has a dead small blind. Heads-up the button posts the small blind.
+func (g *Game) SmallBlindPosition() int {
+	if g.Table != nil {
+		return g.dealtInAt(g.Table.SmallBlindSeat)
+	}
+	if g.HeadsUp() {
+		return g.DealerPosition
+	}
+	return g.nextDealtIn(g.DealerPosition)
+}
+
+// BigBlindPosition returns the position of the big blind.
+func (g *Game) BigBlindPosition() int {
+	if g.Table != nil {
+		return g.dealtInAt(g.Table.BigBlindSeat)
+	}
+	return g.nextDealtIn(g.SmallBlindPosition())
+}
+
+// ActionOrder returns the players who can still act in the order they act on
+// the current betting round: left of the big blind pre-flop and left of the
+// button afterwards. Heads-up this puts the button first pre-flop and last
+// after the flop. Straddlers act last pre-flop, in the order they straddled.
+// Without blinds, as in a button-ante game, action starts left of the button
+// on every round. Stud games follow the bring-in and then the best hand
+// showing instead.
+func (g *Game) ActionOrder() []*player.Player {
+	if g.stud() {
+		return g.studActionOrder()
+	}
+	lastSeat := g.buttonSeat()
+	if g.BettingRound == 0 && g.ButtonAnte == 0 {
+		lastSeat = g.seatOf(g.BigBlindPosition())
+	}
+
+	order := make([]*player.Player, 0)
+	for _, player := range g.playersAfterSeat(lastSeat) {
+		if player.Active && !player.AllIn && (g.BettingRound != 0 || !g.straddled(player)) {
+			order = append(order, player)
+		}
+	}
+	if g.BettingRound == 0 {
+		for _, player := range g.Straddles {
+			if player.Active && !player.AllIn {
+				order = append(order, player)
+			}
+		}
+	}
+	return order
+}
+
+func (g *Game) straddled(p *player.Player) bool {
+	for _, player := range g.Straddles {
+		if player == p {
+			return true
+		}
+	}
+	return false
+}
+
+// buttonSeat returns the seat of the dealer button, which may be empty.
+func (g *Game) buttonSeat() int {
+	if g.Table != nil {
+		return g.Table.DealerPosition
+	}
+	return g.DealerPosition
+}
+
+// seatOf returns the seat of the player at the given position. Without a
+// table, players sit in the order they were given to the game.
+func (g *Game) seatOf(position int) int {
+	if g.Table != nil {
+		return g.Players[position].Seat
+	}
+	return position
+}
+
+// playersAfterSeat returns every player, starting with the first one seated
+// after seat and going clockwise.
+func (g *Game) playersAfterSeat(seat int) []*player.Player {
+	start := 0
+	for i := range g.Players {
+		if g.seatOf(i) > seat {
+			start = i
+			break
+		}
+	}
+	return append(append([]*player.Player{}, g.Players[start:]...), g.Players[:start]...)
+}
+
+// dealtInAt returns the position of the player in the given table seat, or -1
+// if nobody in that seat was dealt into the hand.
+func (g *Game) dealtInAt(seat int) int {
+	for _, player := range g.dealt {
+		if player.Seat == seat {
+			return g.positionOf(player)
+		}
+	}
+	return -1
+}
+
+// nextDealtIn returns the position of the next player after position who was
+// dealt into the hand, whether or not they have folded since.
+func (g *Game) nextDealtIn(position int) int {
+	for i := 1; i <= len(g.Players); i++ {
+		next := (position + i) % len(g.Players)
+		for _, player := range g.dealt {
+			if player == g.Players[next] {
+				return next
+			}
+		}
+	}
+	return -1
+}
+
+// moveButton moves the dealer button to the next player who still has chips.
+func (g *Game) moveButton() {
+	for i := 1; i <= len(g.Players); i++ {
+		next := (g.DealerPosition + i) % len(g.Players)
+		if g.Players[next].Stack > 0 {
+			g.DealerPosition = next
+			return
+		}
+	}
+}
+
+// adjustHeadsUpButton keeps a player from posting the big blind twice in a
+// row when the game drops to heads-up, by giving that player the button.
+func (g *Game) adjustHeadsUpButton() {
+	if !g.HeadsUp() || g.previousBigBlind == nil {
+		return
+	}
+	if g.Players[g.BigBlindPosition()] != g.previousBigBlind {
+		return
+	}
+	for i, player := range g.Players {
+		if player == g.previousBigBlind {
+			g.DealerPosition = i
+			return
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/prfc0/aksha/internal/player"
+)
+
+func TestHeadsUpBlinds(t *testing.T) {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+	}
+	game := NewGame(players, 10, 20)
+	game.StartHand()
+
+	if !game.HeadsUp() {
+		t.Fatal("Expected a two-player hand to be heads-up")
+	}
+	if game.SmallBlindPosition() != 0 || game.BigBlindPosition() != 1 {
+		t.Errorf("Expected the button to post the small blind, got SB %d, BB %d", game.SmallBlindPosition(), game.BigBlindPosition())
+	}
+	if players[0].Stack != 990 || players[1].Stack != 980 {
+		t.Errorf("Expected blinds of 10 and 20, got stacks %d and %d", players[0].Stack, players[1].Stack)
+	}
+
+	order := game.ActionOrder()
+	if order[0].Name != "Alice" {
+		t.Errorf("Expected the button to act first pre-flop, got %s", order[0].Name)
+	}
+
+	game.DealCommunityCards(3)
+	order = game.ActionOrder()
+	if order[0].Name != "Bob" || order[1].Name != "Alice" {
+		t.Errorf("Expected the button to act last after the flop, got %v", order)
+	}
+}
+
+func TestRingActionOrder(t *testing.T) {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+		player.NewPlayer("3", "Charlie", 1000),
+		player.NewPlayer("4", "Dave", 1000),
+	}
+	game := NewGame(players, 10, 20)
+	game.StartHand()
+
+	if game.SmallBlindPosition() != 1 || game.BigBlindPosition() != 2 {
+		t.Errorf("Expected blinds left of the button, got SB %d, BB %d", game.SmallBlindPosition(), game.BigBlindPosition())
+	}
+	if order := game.ActionOrder(); order[0].Name != "Dave" {
+		t.Errorf("Expected the player left of the big blind to act first, got %s", order[0].Name)
+	}
+
+	// The small blind folding does not move the big blind
+	players[1].Fold()
+	if game.BigBlindPosition() != 2 {
+		t.Errorf("Expected the big blind to stay at 2 after a fold, got %d", game.BigBlindPosition())
+	}
+
+	game.DealCommunityCards(3)
+	if order := game.ActionOrder(); order[0].Name != "Charlie" {
+		t.Errorf("Expected the first active player left of the button to act first, got %s", order[0].Name)
+	}
+}
+
+func TestDropToHeadsUp(t *testing.T) {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+		player.NewPlayer("3", "Charlie", 1000),
+	}
+	game := NewGame(players, 10, 20)
+	game.StartHand()
+	if game.Players[game.BigBlindPosition()].Name != "Charlie" {
+		t.Fatalf("Expected Charlie in the big blind")
+	}
+
+	// Alice busts on the button
+	game.Pot.Chips = 0
+	players[0].Stack = 0
+	game.EndHand()
+	game.StartHand()
+
+	if !game.HeadsUp() {
+		t.Fatal("Expected the game to be heads-up")
+	}
+	if players[0].Active || len(players[0].Hand) != 0 {
+		t.Error("Expected a player without chips not to be dealt in")
+	}
+	if game.Players[game.BigBlindPosition()].Name == "Charlie" {
+		t.Error("Expected Charlie not to post the big blind twice in a row")
+	}
+	if game.Players[game.DealerPosition].Name != "Charlie" || game.SmallBlindPosition() != game.DealerPosition {
+		t.Error("Expected Charlie to take the button and post the small blind")
+	}
+}
@@ -0,0 +1,101 @@
+package game
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/prfc0/aksha/internal/card"
+	"github.com/prfc0/aksha/internal/player"
+	"github.com/prfc0/aksha/internal/pot"
+)
+
+// Runout is one of the boards dealt when all-in players agree to run the
+// remaining cards more than once, or one board of a double-board bomb pot.
+type Runout struct {
+	Board      []*card.Card     // Community cards for this runout, including those dealt before it
+	Winners    []*player.Player // Players who won the main pot on this runout, or its high half in a split-pot game
+	LowWinners []*player.Player // Players who won the low half of the main pot on this runout in a split-pot game
+	Chips      int              // Share of the main pot and side pots awarded on this runout
+}
+
+// RunOut deals the remaining community cards the given number of times from
+// the same deck, evaluates each board separately and splits the pot between
+// them. Odd chips go to the earliest runout and, within a runout, to the
+// high half and the first winners in showdown order. Every hand is tabled.
+func (g *Game) RunOut(times int) error {
+	if times < 1 {
+		return fmt.Errorf("cannot run the board %d times", times)
+	}
+	if len(g.activePlayers()) < 2 {
+		return fmt.Errorf("at least two players must be in the hand to run it out")
+	}
+	playersWithChips := 0
+	for _, player := range g.activePlayers() {
+		if !player.AllIn {
+			playersWithChips++
+		}
+	}
+	if playersWithChips > 1 {
+		return fmt.Errorf("betting is not over: %d players still have chips", playersWithChips)
+	}
+
+	remaining := g.boardSize() - len(g.CommunityCards)
+	if remaining == 0 && times > 1 {
+		return fmt.Errorf("the board is complete and cannot be run %d times", times)
+	}
+	if remaining*times > len(g.Deck.Cards) {
+		return fmt.Errorf("not enough cards left to run the board %d times", times)
+	}
+	if g.doubleBoard {
+		return fmt.Errorf("a double-board hand cannot be run out")
+	}
+
+	boards := make([][]*card.Card, 0, times)
+	for i := 0; i < times; i++ {
+		board := append([]*card.Card{}, g.CommunityCards...)
+		for j := 0; j < remaining; j++ {
+			board = append(board, g.Deck.Draw())
+		}
+		boards = append(boards, board)
+		log.Printf("Runout %d: %v\n", i+1, board)
+	}
+	g.splitBoards(boards)
+	g.checkChips("running it out")
+
+	return nil
+}
+
+// splitBoards divides the main pot and each side pot evenly between boards
+// and awards each share to the best hands on its board among the players
+// eligible for the pot, recording the boards as runouts. Odd chips go to
+// the first board. Every hand is tabled.
+func (g *Game) splitBoards(boards [][]*card.Card) {
+	g.Runouts = make([]*Runout, 0, len(boards))
+	for _, board := range boards {
+		g.Runouts = append(g.Runouts, &Runout{Board: board})
+	}
+
+	for _, player := range g.ShowdownOrder() {
+		g.ShowHand(player)
+	}
+
+	for i, side := range g.sidePots() {
+		contenders := g.contenders(side, nil)
+		for j, runout := range g.Runouts {
+			share := pot.NewPot()
+			share.Chips = side.Chips / len(boards)
+			if j == 0 {
+				share.Chips += side.Chips % len(boards)
+			}
+			winners := winnersAmong(g.evaluator(), runout.Board, contenders)
+			lowWinners := g.lowWinnersAmong(runout.Board, contenders)
+			if i == 0 {
+				runout.Winners, runout.LowWinners = winners, lowWinners
+			}
+			runout.Chips += share.Chips
+			log.Printf("Awarding %d chips from pot %d on %v.\n", share.Chips, i+1, runout.Board)
+			share.DistributeSplit(winners, lowWinners)
+		}
+	}
+	g.Pot.Chips = 0
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/prfc0/aksha/internal/card"
+	"github.com/prfc0/aksha/internal/player"
+)
+
+func newAllInGame() *Game {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 0),
+		player.NewPlayer("2", "Bob", 0),
+	}
+	players[0].AllIn, players[1].AllIn = true, true
+	game := NewGame(players, 10, 20)
+	game.Pot.AddChips(1001)
+	game.CommunityCards = []*card.Card{
+		card.NewCard(card.Clubs, card.Two),
+		card.NewCard(card.Diamonds, card.Seven),
+		card.NewCard(card.Hearts, card.Nine),
+	}
+	players[0].Hand = []*card.Card{card.NewCard(card.Hearts, card.Ace), card.NewCard(card.Clubs, card.Ace)}
+	players[1].Hand = []*card.Card{card.NewCard(card.Spades, card.King), card.NewCard(card.Clubs, card.King)}
+	game.Deck.Cards = []*card.Card{
+		card.NewCard(card.Hearts, card.King), // first runout gives Bob a set
+		card.NewCard(card.Clubs, card.Three),
+		card.NewCard(card.Spades, card.Four), // second runout holds for Alice
+		card.NewCard(card.Spades, card.Five),
+	}
+	return game
+}
+
+func TestRunOutTwice(t *testing.T) {
+	game := newAllInGame()
+
+	if err := game.RunOut(2); err != nil {
+		t.Fatalf("Unexpected error running it twice: %v", err)
+	}
+
+	if len(game.Runouts) != 2 {
+		t.Fatalf("Expected two runouts, got %d", len(game.Runouts))
+	}
+	for _, runout := range game.Runouts {
+		if len(runout.Board) != 5 {
+			t.Errorf("Expected a complete board, got %v", runout.Board)
+		}
+	}
+	if game.Runouts[0].Winners[0].Name != "Bob" || game.Runouts[1].Winners[0].Name != "Alice" {
+		t.Error("Expected Bob to win the first runout and Alice the second")
+	}
+	if game.Players[1].Stack != 501 || game.Players[0].Stack != 500 {
+		t.Errorf("Expected the pot to be split 501/500, got %d/%d", game.Players[1].Stack, game.Players[0].Stack)
+	}
+	if game.Pot.Chips != 0 {
+		t.Errorf("Expected the pot to be empty, got %d", game.Pot.Chips)
+	}
+	if len(game.Shown["1"]) != 2 || len(game.Shown["2"]) != 2 {
+		t.Error("Expected all-in hands to be tabled")
+	}
+
+	game.EndHand()
+	if game.Players[0].Stack+game.Players[1].Stack != 1001 {
+		t.Error("Expected EndHand not to award a run-out pot again")
+	}
+}
+
+func TestRunOutErrors(t *testing.T) {
+	game := newAllInGame()
+	if err := game.RunOut(0); err == nil {
+		t.Error("Expected an error running the board zero times")
+	}
+	if err := game.RunOut(3); err == nil {
+		t.Error("Expected an error when the deck cannot supply three runouts")
+	}
+
+	game.Players[0].Stack, game.Players[0].AllIn = 100, false
+	game.Players[1].Stack, game.Players[1].AllIn = 100, false
+	if err := game.RunOut(2); err == nil {
+		t.Error("Expected an error when betting is not over")
+	}
+}
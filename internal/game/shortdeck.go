@@ -0,0 +1,16 @@
+package game
+
+import (
+	"github.com/prfc0/aksha/internal/player"
+)
+
+// NewShortDeckGame initializes a no-limit short-deck Hold'em game dealt from
+// a 36-card deck. Every player antes, the button posts buttonAnte on top of
+// their ante, and there are no blinds. Use NewShortDeckVariant for three of
+// a kind to beat a straight.
+func NewShortDeckGame(players []*player.Player, ante, buttonAnte int) *Game {
+	g := NewVariantGame(ShortDeckHoldem, players, 0, 0)
+	g.Ante = ante
+	g.ButtonAnte = buttonAnte
+	return g
+}
@@ -0,0 +1,83 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/prfc0/aksha/internal/action"
+	"github.com/prfc0/aksha/internal/card"
+	"github.com/prfc0/aksha/internal/player"
+)
+
+func TestShortDeckButtonAnte(t *testing.T) {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+		player.NewPlayer("3", "Charlie", 1000),
+	}
+	game := NewShortDeckGame(players, 1, 2)
+	game.StartHand()
+	alice, bob := game.Players[0], game.Players[1]
+
+	if len(game.Deck.Cards) != 36-6 {
+		t.Errorf("Expected a 36-card deck, got %d cards left after the deal", len(game.Deck.Cards))
+	}
+	if game.Pot.Chips != 5 || alice.Committed != 3 || game.CurrentBet != 0 {
+		t.Errorf("Expected antes and a button ante with no blinds, got pot %d and current bet %d", game.Pot.Chips, game.CurrentBet)
+	}
+	if order := game.ActionOrder(); order[0] != bob || order[2] != alice {
+		t.Errorf("Expected action to start left of the button and end on it, got %v", order)
+	}
+}
+
+func TestShortDeckLegalActions(t *testing.T) {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+		player.NewPlayer("3", "Charlie", 1000),
+	}
+	game := NewShortDeckGame(players, 5, 10)
+	game.StartHand()
+
+	// Without blinds the smallest bet is the button ante, before and after
+	// the flop
+	bob := game.Players[1]
+	for _, street := range []string{"pre-flop", "flop"} {
+		choices := game.LegalActions(bob)
+		if len(choices) != 2 || choices[1].Type != action.Bet || choices[1].Min != 10 {
+			t.Errorf("%s: expected a check or a bet of at least 10, got %v", street, choices)
+		}
+		game.DealCommunityCards(3)
+	}
+	if err := game.PerformAction(bob, action.NewAction(action.Bet, 5)); err == nil {
+		t.Error("Expected error betting less than the button ante")
+	}
+}
+
+func TestShortDeckShowdown(t *testing.T) {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+	}
+	game := NewShortDeckGame(players, 1, 2)
+	game.CommunityCards = []*card.Card{
+		card.NewCard(card.Hearts, card.King),
+		card.NewCard(card.Hearts, card.Queen),
+		card.NewCard(card.Spades, card.Queen),
+		card.NewCard(card.Hearts, card.Seven),
+		card.NewCard(card.Clubs, card.Six),
+	}
+	// Alice's flush beats Bob's full house
+	players[0].Hand = []*card.Card{
+		card.NewCard(card.Hearts, card.Ace),
+		card.NewCard(card.Hearts, card.Nine),
+	}
+	players[1].Hand = []*card.Card{
+		card.NewCard(card.Spades, card.King),
+		card.NewCard(card.Clubs, card.King),
+	}
+
+	winners := game.DetermineWinner()
+	if len(winners) != 1 || winners[0].Name != "Alice" {
+		t.Errorf("Expected Alice to win, got %v", winners)
+	}
+}
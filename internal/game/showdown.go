@@ -0,0 +1,144 @@
+package game
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/prfc0/aksha/internal/card"
+	"github.com/prfc0/aksha/internal/hand"
+	"github.com/prfc0/aksha/internal/player"
+)
+
+// MuckFunc decides whether a player whose hand is beaten at showdown mucks it.
+type MuckFunc func(p *player.Player) bool
+
+// ShowdownOrder returns the players still in the hand in the order they show.
+// The last aggressor on the final betting round shows first; if there was no
+// betting, the first active player left of the button does.
+func (g *Game) ShowdownOrder() []*player.Player {
+	lastSeat := g.buttonSeat()
+	if g.LastAggressor != nil && g.LastAggressor.Active {
+		lastSeat = g.seatOf(g.positionOf(g.LastAggressor)) - 1
+	}
+
+	order := make([]*player.Player, 0)
+	for _, player := range g.playersAfterSeat(lastSeat) {
+		if player.Active {
+			order = append(order, player)
+		}
+	}
+	return order
+}
+
+// Showdown reveals the remaining hands in showdown order and returns the
+// winners. A hand that beats or ties the best hand shown so far must be
+// shown, as must a hand winning the low in a split-pot game. A beaten hand
+// may be mucked, except that all-in players' hands are always tabled.
+func (g *Game) Showdown() []*player.Player {
+	winners, _ := g.showdown(g.ShowdownOrder(), make(map[string]bool))
+	return winners
+}
+
+// showdown reveals the hands of contenders, given in showdown order, for
+// one pot and returns its high and low winners. Players who muck are added
+// to mucked.
+func (g *Game) showdown(contenders []*player.Player, mucked map[string]bool) ([]*player.Player, []*player.Player) {
+	var best hand.Ranked
+	evaluator := g.evaluator()
+	winners := make([]*player.Player, 0)
+	lowWinners := g.lowWinnersAmong(g.CommunityCards, contenders)
+
+	for _, contender := range contenders {
+		playerHand := evaluator.Evaluate(contender.Hand, g.CommunityCards)
+		comparison := 1
+		if best != nil {
+			comparison = evaluator.Compare(playerHand, best)
+		}
+
+		if comparison < 0 && !contender.AllIn && !contains(lowWinners, contender) && g.Muck != nil && g.Muck(contender) {
+			log.Printf("Player %s mucks.\n", contender.Name)
+			mucked[contender.ID] = true
+			continue
+		}
+
+		g.ShowHand(contender)
+		log.Printf("Player %s shows %v.\n", contender.Name, playerHand)
+
+		switch {
+		case comparison > 0:
+			best = playerHand
+			winners = []*player.Player{contender}
+		case comparison == 0:
+			winners = append(winners, contender)
+		}
+	}
+
+	return winners, lowWinners
+}
+
+// winnersAmong returns the contenders holding the best hand on the given
+// board by the evaluator's ranking, in the order given. Players without a
+// qualifying hand cannot win.
+func winnersAmong(evaluator hand.Evaluator, board []*card.Card, contenders []*player.Player) []*player.Player {
+	var best hand.Ranked
+	winners := make([]*player.Player, 0)
+	for _, contender := range contenders {
+		contenderHand := evaluator.Evaluate(contender.Hand, board)
+		if contenderHand == nil {
+			continue
+		}
+		comparison := 1
+		if best != nil {
+			comparison = evaluator.Compare(contenderHand, best)
+		}
+		switch {
+		case comparison > 0:
+			best = contenderHand
+			winners = []*player.Player{contender}
+		case comparison == 0:
+			winners = append(winners, contender)
+		}
+	}
+	return winners
+}
+
+// ShowHand turns all of a player's hole cards face up, for example at
+// showdown or after winning a pot uncontested.
+func (g *Game) ShowHand(p *player.Player) {
+	g.Shown[p.ID] = append([]*card.Card{}, p.Hand...)
+}
+
+// ShowCard turns a single one of a player's hole cards face up.
+func (g *Game) ShowCard(p *player.Player, index int) error {
+	if index < 0 || index >= len(p.Hand) {
+		return fmt.Errorf("player %s has no card at position %d", p.Name, index)
+	}
+	shownCard := p.Hand[index]
+	if !g.isShown(p, shownCard) {
+		g.Shown[p.ID] = append(g.Shown[p.ID], shownCard)
+		log.Printf("Player %s shows %s.\n", p.Name, shownCard.String())
+	}
+	return nil
+}
+
+// VisibleHand returns the hole cards of p as seen by the player with the
+// given ID. Cards the viewer is not allowed to see are nil; stud up cards
+// are seen by everyone.
+func (g *Game) VisibleHand(viewerID string, p *player.Player) []*card.Card {
+	visible := make([]*card.Card, len(p.Hand))
+	for i, holeCard := range p.Hand {
+		if viewerID == p.ID || g.isShown(p, holeCard) || g.isUp(p, holeCard) {
+			visible[i] = holeCard
+		}
+	}
+	return visible
+}
+
+func (g *Game) isShown(p *player.Player, c *card.Card) bool {
+	for _, shownCard := range g.Shown[p.ID] {
+		if shownCard == c {
+			return true
+		}
+	}
+	return false
+}
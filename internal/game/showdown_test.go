@@ -0,0 +1,119 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/prfc0/aksha/internal/card"
+	"github.com/prfc0/aksha/internal/player"
+)
+
+func newShowdownGame() *Game {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+		player.NewPlayer("3", "Charlie", 1000),
+	}
+	game := NewGame(players, 10, 20)
+	game.CommunityCards = []*card.Card{
+		card.NewCard(card.Spades, card.Two),
+		card.NewCard(card.Hearts, card.Seven),
+		card.NewCard(card.Diamonds, card.Nine),
+		card.NewCard(card.Clubs, card.Jack),
+		card.NewCard(card.Spades, card.King),
+	}
+	// Alice: pair of kings, Bob: pair of aces, Charlie: ace high
+	players[0].Hand = []*card.Card{card.NewCard(card.Hearts, card.King), card.NewCard(card.Clubs, card.Three)}
+	players[1].Hand = []*card.Card{card.NewCard(card.Hearts, card.Ace), card.NewCard(card.Clubs, card.Ace)}
+	players[2].Hand = []*card.Card{card.NewCard(card.Diamonds, card.Ace), card.NewCard(card.Clubs, card.Four)}
+	return game
+}
+
+func TestShowdownOrder(t *testing.T) {
+	game := newShowdownGame()
+
+	order := game.ShowdownOrder()
+	if order[0].Name != "Bob" || order[1].Name != "Charlie" || order[2].Name != "Alice" {
+		t.Errorf("Expected first player left of the button to show first, got %v", order)
+	}
+
+	game.LastAggressor = game.Players[2]
+	order = game.ShowdownOrder()
+	if order[0].Name != "Charlie" || order[1].Name != "Alice" || order[2].Name != "Bob" {
+		t.Errorf("Expected last aggressor to show first, got %v", order)
+	}
+}
+
+func TestShowdownMuck(t *testing.T) {
+	game := newShowdownGame()
+	game.LastAggressor = game.Players[0]
+	game.Muck = func(p *player.Player) bool { return true }
+
+	winners := game.Showdown()
+	if len(winners) != 1 || winners[0].Name != "Bob" {
+		t.Fatalf("Expected Bob to win, got %v", winners)
+	}
+	// Alice shows first, Bob beats Alice and must show, Charlie is beaten and mucks
+	if len(game.Shown["1"]) != 2 || len(game.Shown["2"]) != 2 {
+		t.Error("Expected Alice and Bob to show their hands")
+	}
+	if len(game.Shown["3"]) != 0 {
+		t.Error("Expected Charlie to muck a beaten hand")
+	}
+}
+
+func TestShowdownAllInCannotMuck(t *testing.T) {
+	game := newShowdownGame()
+	game.LastAggressor = game.Players[0]
+	game.Muck = func(p *player.Player) bool { return true }
+	game.Players[2].Stack = 0
+	game.Players[2].AllIn = true
+
+	game.Showdown()
+	if len(game.Shown["3"]) != 2 {
+		t.Error("Expected all-in player's hand to be tabled")
+	}
+}
+
+func TestVisibleHand(t *testing.T) {
+	game := newShowdownGame()
+	alice := game.Players[0]
+
+	if visible := game.VisibleHand("1", alice); visible[0] == nil || visible[1] == nil {
+		t.Error("Expected a player to see their own hole cards")
+	}
+	if visible := game.VisibleHand("2", alice); visible[0] != nil || visible[1] != nil {
+		t.Error("Expected hole cards to be hidden from other players")
+	}
+
+	if err := game.ShowCard(alice, 1); err != nil {
+		t.Fatalf("Unexpected error showing a card: %v", err)
+	}
+	visible := game.VisibleHand("2", alice)
+	if visible[0] != nil || visible[1] != alice.Hand[1] {
+		t.Error("Expected only the shown card to be visible")
+	}
+
+	if err := game.ShowCard(alice, 2); err == nil {
+		t.Error("Expected an error showing a card the player does not hold")
+	}
+}
+
+func TestEndHandUncontested(t *testing.T) {
+	game := newShowdownGame()
+	game.Pot.AddChips(300)
+	game.Players[1].Fold()
+	game.Players[2].Fold()
+
+	game.EndHand()
+	if game.Players[0].Stack != 1300 {
+		t.Errorf("Expected Alice to win the pot uncontested, got stack %d", game.Players[0].Stack)
+	}
+	if len(game.Shown["1"]) != 0 {
+		t.Error("Expected an uncontested winner not to show")
+	}
+
+	game.ShowHand(game.Players[0])
+	if visible := game.VisibleHand("2", game.Players[0]); visible[0] == nil {
+		t.Error("Expected the winner's hand to be visible after showing")
+	}
+}
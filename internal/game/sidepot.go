@@ -0,0 +1,57 @@
+package game
+
+import (
+	"log"
+
+	"github.com/prfc0/aksha/internal/player"
+	"github.com/prfc0/aksha/internal/pot"
+)
+
+// sidePots splits the pot into a main pot and side pots by what each player
+// dealt in committed. Chips in the pot that no player committed go to the
+// main pot.
+func (g *Game) sidePots() []*pot.Pot {
+	pots := pot.SidePots(g.dealt)
+	if len(pots) == 0 {
+		pots = []*pot.Pot{{Eligible: g.activePlayers()}}
+	}
+	counted := 0
+	for _, side := range pots {
+		counted += side.Chips
+	}
+	pots[0].Chips += g.Pot.Chips - counted
+	return pots
+}
+
+// contenders returns the players eligible for a pot who have not mucked, in
+// showdown order.
+func (g *Game) contenders(side *pot.Pot, mucked map[string]bool) []*player.Player {
+	contenders := make([]*player.Player, 0)
+	for _, player := range g.ShowdownOrder() {
+		if contains(side.Eligible, player) && !mucked[player.ID] {
+			contenders = append(contenders, player)
+		}
+	}
+	return contenders
+}
+
+// awardPots awards the main pot and each side pot to the best hands among
+// the players eligible for it. Side pots are shown down first, from the
+// last, and a player who mucks gives up every pot. A pot with a single
+// contender is won without a showdown.
+func (g *Game) awardPots() {
+	pots := g.sidePots()
+	mucked := make(map[string]bool)
+	for i := len(pots) - 1; i >= 0; i-- {
+		contenders := g.contenders(pots[i], mucked)
+		winners, lowWinners := contenders, make([]*player.Player, 0)
+		if len(contenders) > 1 {
+			winners, lowWinners = g.showdown(contenders, mucked)
+		}
+		if len(pots) > 1 {
+			log.Printf("Awarding pot %d of %d chips.\n", i+1, pots[i].Chips)
+		}
+		pots[i].DistributeSplit(winners, lowWinners)
+	}
+	g.Pot.Chips = 0
+}
@@ -0,0 +1,75 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/prfc0/aksha/internal/action"
+	"github.com/prfc0/aksha/internal/card"
+	"github.com/prfc0/aksha/internal/player"
+)
+
+// newSidePotGame starts a hand in which players with 100, 300 and 1000
+// chips all go all-in before the flop: Alice with aces, Bob with queens and
+// Charlie with nothing.
+func newSidePotGame(t *testing.T) *Game {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 100),
+		player.NewPlayer("2", "Bob", 300),
+		player.NewPlayer("3", "Charlie", 1000),
+	}
+	game := NewGame(players, 10, 20)
+	if err := game.StartHand(); err != nil {
+		t.Fatalf("Unexpected error starting the hand: %v", err)
+	}
+	for _, p := range game.ActionOrder() {
+		if err := game.PerformAction(p, action.NewAction(action.Raise, p.Stack)); err != nil {
+			t.Fatalf("Unexpected error going all-in: %v", err)
+		}
+	}
+
+	players[0].Hand = []*card.Card{card.NewCard(card.Hearts, card.Ace), card.NewCard(card.Clubs, card.Ace)}
+	players[1].Hand = []*card.Card{card.NewCard(card.Hearts, card.Queen), card.NewCard(card.Clubs, card.Queen)}
+	players[2].Hand = []*card.Card{card.NewCard(card.Diamonds, card.Three), card.NewCard(card.Clubs, card.Four)}
+	game.CommunityCards = []*card.Card{
+		card.NewCard(card.Spades, card.Two),
+		card.NewCard(card.Hearts, card.Seven),
+		card.NewCard(card.Diamonds, card.Nine),
+	}
+	return game
+}
+
+func TestSidePots(t *testing.T) {
+	game := newSidePotGame(t)
+	game.CommunityCards = append(game.CommunityCards, card.NewCard(card.Spades, card.Jack), card.NewCard(card.Diamonds, card.King))
+
+	game.EndHand()
+	// Alice wins the 300 main pot, Bob the 400 side pot and Charlie gets
+	// back the 700 nobody called
+	alice, bob, charlie := game.Players[0], game.Players[1], game.Players[2]
+	if alice.Stack != 300 || bob.Stack != 400 || charlie.Stack != 700 {
+		t.Errorf("Expected stacks 300, 400 and 700, got %d, %d and %d", alice.Stack, bob.Stack, charlie.Stack)
+	}
+}
+
+func TestSidePotsRunOutTwice(t *testing.T) {
+	game := newSidePotGame(t)
+	game.Deck.Cards = []*card.Card{
+		card.NewCard(card.Spades, card.Jack), // first runout holds for Alice
+		card.NewCard(card.Diamonds, card.King),
+		card.NewCard(card.Spades, card.Queen), // second runout gives Bob a set
+		card.NewCard(card.Clubs, card.Five),
+	}
+
+	if err := game.RunOut(2); err != nil {
+		t.Fatalf("Unexpected error running it twice: %v", err)
+	}
+	// Each pot is split between the boards: Alice wins half the main pot,
+	// Bob the other half and all of the side pot
+	alice, bob, charlie := game.Players[0], game.Players[1], game.Players[2]
+	if alice.Stack != 150 || bob.Stack != 550 || charlie.Stack != 700 {
+		t.Errorf("Expected stacks 150, 550 and 700, got %d, %d and %d", alice.Stack, bob.Stack, charlie.Stack)
+	}
+	if game.Runouts[0].Chips != 700 || game.Runouts[1].Chips != 700 {
+		t.Errorf("Expected 700 chips awarded on each runout, got %d and %d", game.Runouts[0].Chips, game.Runouts[1].Chips)
+	}
+}
@@ -0,0 +1,143 @@
+package game
+
+import (
+	"log"
+
+	"github.com/prfc0/aksha/internal/card"
+	"github.com/prfc0/aksha/internal/hand"
+	"github.com/prfc0/aksha/internal/player"
+)
+
+// NewStudGame initializes a fixed-limit Seven-Card Stud game. Every player
+// antes, the lowest up card brings it in, and bets are smallBet on third and
+// fourth street and bigBet afterwards.
+func NewStudGame(players []*player.Player, ante, bringIn, smallBet, bigBet int) *Game {
+	g := NewVariantGame(SevenCardStud, players, 0, 0)
+	g.Ante = ante
+	g.BringIn = bringIn
+	g.SmallBet = smallBet
+	g.BigBet = bigBet
+	return g
+}
+
+// NewRazzGame initializes a fixed-limit Razz game, dealt and bet like
+// Seven-Card Stud but won by the best ace-to-five low. The highest up card
+// brings it in.
+func NewRazzGame(players []*player.Player, ante, bringIn, smallBet, bigBet int) *Game {
+	g := NewStudGame(players, ante, bringIn, smallBet, bigBet)
+	g.Variant = Razz
+	return g
+}
+
+// stud reports whether the variant is played like stud, with a bring-in and
+// action led by the players' up cards.
+func (g *Game) stud() bool {
+	return g.Variant.ForcedBets() != Blinds
+}
+
+// UpCards returns a player's face-up cards in the order they were dealt.
+func (g *Game) UpCards(p *player.Player) []*card.Card {
+	return g.upCards[p.ID]
+}
+
+// BringInPosition returns the position of the player showing the lowest up
+// card, with suits breaking ties from clubs up to spades, or -1 before any
+// up cards are dealt. In Razz the highest up card brings it in, aces low and
+// spades breaking ties first.
+func (g *Game) BringInPosition() int {
+	position := -1
+	var worst *card.Card
+	for _, player := range g.dealt {
+		up := g.upCards[player.ID]
+		if len(up) == 0 {
+			continue
+		}
+		if worst == nil || g.bringsIn(up[0], worst) {
+			worst = up[0]
+			position = g.positionOf(player)
+		}
+	}
+	return position
+}
+
+// bringsIn reports whether a player showing c brings it in ahead of one
+// showing other.
+func (g *Game) bringsIn(c, other *card.Card) bool {
+	value, otherValue := c.Value(), other.Value()
+	suit, otherSuit := c.Suit.Order(), other.Suit.Order()
+	if g.Variant.ForcedBets() == BringInHigh {
+		value, otherValue = -acesLow(c), -acesLow(other)
+		suit, otherSuit = -suit, -otherSuit
+	}
+	return value < otherValue || (value == otherValue && suit < otherSuit)
+}
+
+// acesLow returns a card's value with aces counting as 1.
+func acesLow(c *card.Card) int {
+	if c.Rank == card.Ace {
+		return 1
+	}
+	return c.Value()
+}
+
+// postBringIn makes the player with the lowest up card post the bring-in,
+// which later players may call or complete to a full small bet.
+func (g *Game) postBringIn() {
+	position := g.BringInPosition()
+	if position == -1 || g.BringIn == 0 {
+		return
+	}
+	g.bringIn = g.Players[position]
+	g.postBlind(g.bringIn, g.BringIn)
+	log.Printf("Player %s brings it in for %d.\n", g.bringIn.Name, g.BringIn)
+}
+
+// studActionOrder returns the players who can still act in stud. On third
+// street action starts left of the bring-in; on later streets the best hand
+// showing acts first, the earliest player winning ties.
+func (g *Game) studActionOrder() []*player.Player {
+	first := 0
+	if g.BettingRound == 0 && g.bringIn != nil {
+		first = (g.positionOf(g.bringIn) + 1) % len(g.Players)
+	} else if best := g.bestShowingPosition(); best != -1 {
+		first = best
+	}
+
+	order := make([]*player.Player, 0)
+	for i := 0; i < len(g.Players); i++ {
+		player := g.Players[(first+i)%len(g.Players)]
+		if player.Active && !player.AllIn {
+			order = append(order, player)
+		}
+	}
+	return order
+}
+
+// bestShowingPosition returns the position of the player still in the hand
+// whose up cards make the best hand, ranked as the whole or high half of the
+// pot is.
+func (g *Game) bestShowingPosition() int {
+	position := -1
+	var best hand.Ranked
+	evaluator := g.evaluator()
+	for i, player := range g.Players {
+		if !player.Active || len(g.upCards[player.ID]) == 0 {
+			continue
+		}
+		showing := evaluator.Evaluate(g.upCards[player.ID], nil)
+		if best == nil || evaluator.Compare(showing, best) > 0 {
+			best = showing
+			position = i
+		}
+	}
+	return position
+}
+
+func (g *Game) isUp(p *player.Player, c *card.Card) bool {
+	for _, up := range g.upCards[p.ID] {
+		if up == c {
+			return true
+		}
+	}
+	return false
+}
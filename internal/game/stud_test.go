@@ -0,0 +1,174 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/prfc0/aksha/internal/action"
+	"github.com/prfc0/aksha/internal/card"
+	"github.com/prfc0/aksha/internal/player"
+)
+
+func newStudGame() *Game {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+		player.NewPlayer("3", "Charlie", 1000),
+	}
+	game := NewStudGame(players, 1, 2, 5, 10)
+	game.Deck.Cards = []*card.Card{
+		// Two down cards each
+		card.NewCard(card.Spades, card.Nine), card.NewCard(card.Hearts, card.Nine), card.NewCard(card.Clubs, card.Nine),
+		card.NewCard(card.Spades, card.Ten), card.NewCard(card.Hearts, card.Ten), card.NewCard(card.Clubs, card.Ten),
+		// Third street up cards: Bob and Charlie show threes
+		card.NewCard(card.Hearts, card.King), card.NewCard(card.Diamonds, card.Three), card.NewCard(card.Clubs, card.Three),
+		// Fourth street pairs Bob's door card
+		card.NewCard(card.Spades, card.Two), card.NewCard(card.Hearts, card.Three), card.NewCard(card.Hearts, card.Ace),
+	}
+	return game
+}
+
+// laterStreets returns enough cards to deal three players fifth through
+// seventh street.
+func laterStreets() []*card.Card {
+	cards := make([]*card.Card, 0)
+	for _, rank := range []card.Rank{card.Four, card.Five, card.Six} {
+		for _, suit := range []card.Suit{card.Clubs, card.Diamonds, card.Spades} {
+			cards = append(cards, card.NewCard(suit, rank))
+		}
+	}
+	return cards
+}
+
+func TestStudDeal(t *testing.T) {
+	game := newStudGame()
+	game.StartHand()
+	alice, bob := game.Players[0], game.Players[1]
+
+	if len(alice.Hand) != 3 || len(game.UpCards(alice)) != 1 {
+		t.Fatalf("Expected two down cards and one up card, got %d cards with %d up", len(alice.Hand), len(game.UpCards(alice)))
+	}
+	seen := game.VisibleHand(bob.ID, alice)
+	if seen[0] != nil || seen[1] != nil || seen[2] == nil {
+		t.Errorf("Expected Bob to see only Alice's up card, got %v", seen)
+	}
+	if own := game.VisibleHand(alice.ID, alice); own[0] == nil || own[1] == nil {
+		t.Error("Expected Alice to see her own down cards")
+	}
+
+	game.Deck.Cards = append(game.Deck.Cards, laterStreets()...)
+	for street := 4; street <= 7; street++ {
+		game.DealStreet()
+	}
+	if len(alice.Hand) != 7 || len(game.UpCards(alice)) != 4 {
+		t.Errorf("Expected seven cards with four up by seventh street, got %d with %d up", len(alice.Hand), len(game.UpCards(alice)))
+	}
+	if game.VisibleHand(bob.ID, alice)[6] != nil {
+		t.Error("Expected the seventh-street card to be dealt down")
+	}
+}
+
+func TestStudSeventhStreetCommunityCard(t *testing.T) {
+	game := newStudGame()
+	game.StartHand()
+	game.Deck.Cards = append(game.Deck.Cards, laterStreets()...)
+	for street := 4; street <= 6; street++ {
+		game.DealStreet()
+	}
+	game.Deck.Cards = game.Deck.Cards[:1]
+
+	game.DealStreet()
+	if len(game.CommunityCards) != 1 || len(game.Players[0].Hand) != 6 {
+		t.Errorf("Expected a shared seventh-street card when the deck runs short, got %d community cards", len(game.CommunityCards))
+	}
+}
+
+func TestStudBringInAndBetting(t *testing.T) {
+	game := newStudGame()
+	game.StartHand()
+	alice, bob, charlie := game.Players[0], game.Players[1], game.Players[2]
+
+	// Charlie's three of clubs is lower than Bob's three of diamonds
+	if game.BringInPosition() != 2 || charlie.Bet != 2 || game.Pot.Chips != 3+2 {
+		t.Fatalf("Expected Charlie to bring it in for 2 after antes, got position %d, bet %d", game.BringInPosition(), charlie.Bet)
+	}
+	if order := game.ActionOrder(); order[0] != alice || order[2] != charlie {
+		t.Errorf("Expected action to start left of the bring-in, got %v", order)
+	}
+
+	// Alice completes to the small bet, and Bob may only raise by one bet
+	if game.MaxAmount(alice) != 5 {
+		t.Errorf("Expected Alice to complete to 5, got %d", game.MaxAmount(alice))
+	}
+	game.PerformAction(alice, action.NewAction(action.Raise, 5))
+	if err := game.PerformAction(bob, action.NewAction(action.Raise, 8)); err == nil {
+		t.Error("Expected error raising less than a full bet in fixed-limit")
+	}
+	if err := game.PerformAction(bob, action.NewAction(action.Raise, 10)); err != nil {
+		t.Errorf("Unexpected error raising one bet: %v", err)
+	}
+	game.PerformAction(charlie, action.NewAction(action.Call, 8))
+	game.PerformAction(alice, action.NewAction(action.Raise, 10))
+	game.PerformAction(bob, action.NewAction(action.Raise, 10))
+	if game.MaxAmount(charlie) != 10 {
+		t.Errorf("Expected the round to be capped at four bets, got Charlie's max of %d", game.MaxAmount(charlie))
+	}
+
+	// Bob's pair of threes showing acts first on fourth street
+	game.DealStreet()
+	if order := game.ActionOrder(); order[0] != bob {
+		t.Errorf("Expected Bob's pair showing to act first, got %v", order)
+	}
+	if game.MaxAmount(bob) != 5 {
+		t.Errorf("Expected a small bet of 5 on fourth street, got %d", game.MaxAmount(bob))
+	}
+}
+
+func TestRazz(t *testing.T) {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+		player.NewPlayer("3", "Charlie", 1000),
+	}
+	game := NewRazzGame(players, 1, 2, 5, 10)
+	game.Deck.Cards = []*card.Card{
+		card.NewCard(card.Spades, card.Nine), card.NewCard(card.Hearts, card.Nine), card.NewCard(card.Clubs, card.Nine),
+		card.NewCard(card.Spades, card.Ten), card.NewCard(card.Hearts, card.Ten), card.NewCard(card.Clubs, card.Ten),
+		// Alice and Bob show kings, Charlie's ace is low
+		card.NewCard(card.Diamonds, card.King), card.NewCard(card.Spades, card.King), card.NewCard(card.Spades, card.Ace),
+		card.NewCard(card.Spades, card.Two), card.NewCard(card.Hearts, card.Three), card.NewCard(card.Hearts, card.Four),
+	}
+	game.StartHand()
+	alice, bob, charlie := game.Players[0], game.Players[1], game.Players[2]
+
+	// The king of spades is the highest up card
+	if game.BringInPosition() != 1 || bob.Bet != 2 {
+		t.Errorf("Expected Bob to bring it in, got position %d", game.BringInPosition())
+	}
+
+	// Charlie's ace-four is the best low showing
+	game.DealStreet()
+	if order := game.ActionOrder(); order[0] != charlie {
+		t.Errorf("Expected Charlie to act first, got %v", order)
+	}
+
+	alice.Hand = []*card.Card{
+		card.NewCard(card.Diamonds, card.Eight), card.NewCard(card.Spades, card.Seven), card.NewCard(card.Diamonds, card.Six),
+		card.NewCard(card.Clubs, card.Five), card.NewCard(card.Diamonds, card.Four), card.NewCard(card.Diamonds, card.Three),
+		card.NewCard(card.Diamonds, card.Two),
+	}
+	bob.Hand = []*card.Card{
+		card.NewCard(card.Clubs, card.Eight), card.NewCard(card.Hearts, card.Eight), card.NewCard(card.Clubs, card.Six),
+		card.NewCard(card.Hearts, card.Five), card.NewCard(card.Clubs, card.Four), card.NewCard(card.Clubs, card.Three),
+		card.NewCard(card.Clubs, card.Ace),
+	}
+	charlie.Hand = []*card.Card{
+		card.NewCard(card.Spades, card.Queen), card.NewCard(card.Hearts, card.Queen), card.NewCard(card.Clubs, card.Queen),
+		card.NewCard(card.Spades, card.Jack), card.NewCard(card.Hearts, card.Jack), card.NewCard(card.Clubs, card.Jack),
+		card.NewCard(card.Spades, card.Six),
+	}
+	// Bob's 6-5-4-3-A beats Alice's 6-5-4-3-2, whose straight does not count
+	winners := game.DetermineWinner()
+	if len(winners) != 1 || winners[0] != bob {
+		t.Errorf("Expected Bob to win, got %v", winners)
+	}
+}
@@ -0,0 +1,285 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/prfc0/aksha/internal/action"
+	"github.com/prfc0/aksha/internal/deck"
+	"github.com/prfc0/aksha/internal/hand"
+	"github.com/prfc0/aksha/internal/player"
+)
+
+// Variant describes a form of poker: how the cards are dealt, how the
+// betting is structured, how hands are ranked and which forced bets start
+// the action. A Game plays any Variant, so adding a game means describing
+// it rather than changing the engine.
+type Variant interface {
+	String() string
+	// Streets returns what is dealt before each betting round, starting
+	// with the opening deal.
+	Streets() []Street
+	// Limit returns the betting limit the variant is played with.
+	Limit() BettingLimit
+	// SmallBetRounds returns how many betting rounds use the small bet in
+	// fixed-limit before the big bet.
+	SmallBetRounds() int
+	// Evaluators returns how hands are ranked for the whole pot, or its
+	// high half, and for the low half of a split pot. low is nil if the
+	// pot is not split.
+	Evaluators() (high, low hand.Evaluator)
+	// ForcedBets returns which forced bets open the betting, besides antes.
+	ForcedBets() ForcedBets
+	// NewDeck returns a new, unshuffled deck to deal a hand from.
+	NewDeck() *deck.Deck
+}
+
+// Street is what is dealt before one betting round.
+type Street struct {
+	Down  int  // Cards dealt face down to each player
+	Up    int  // Cards dealt face up to each player
+	Board int  // Community cards dealt face up for everyone
+	Draw  bool // Whether players discard and draw replacements instead
+}
+
+// ForcedBets says which forced bets open the first betting round.
+type ForcedBets int
+
+const (
+	Blinds      ForcedBets = iota // Small and big blinds, or a button ante in their place
+	BringInLow                    // The lowest up card brings it in, as in stud
+	BringInHigh                   // The highest up card brings it in, aces low, as in Razz
+)
+
+// BettingLimit caps how much a player may bet or raise.
+type BettingLimit int
+
+const (
+	NoLimit    BettingLimit = iota // Up to the whole stack
+	PotLimit                       // Up to the size of the pot after calling
+	FixedLimit                     // One small or big bet at a time, up to four bets a round unless the game sets otherwise
+)
+
+// maxBets is the number of bets and raises allowed on each fixed-limit
+// betting round.
+const maxBets = 4
+
+// Rules is a Variant described by data. Every variant the engine ships
+// with is a Rules.
+type Rules struct {
+	Name         string         // Name of the game
+	Deal         []Street       // What is dealt before each betting round
+	BettingLimit BettingLimit   // Betting limit the game is played with
+	SmallBets    int            // Betting rounds played with the small bet in fixed-limit
+	High         hand.Evaluator // Ranking of the whole pot, or its high half
+	Low          hand.Evaluator // Ranking of the low half of a split pot; nil if the pot is not split
+	Forced       ForcedBets     // Forced bets that open the betting
+	ShortDeck    bool           // Whether the twos through fives are left out of the deck
+}
+
+func (r *Rules) String() string                         { return r.Name }
+func (r *Rules) Streets() []Street                      { return r.Deal }
+func (r *Rules) Limit() BettingLimit                    { return r.BettingLimit }
+func (r *Rules) SmallBetRounds() int                    { return r.SmallBets }
+func (r *Rules) Evaluators() (high, low hand.Evaluator) { return r.High, r.Low }
+func (r *Rules) ForcedBets() ForcedBets                 { return r.Forced }
+
+func (r *Rules) NewDeck() *deck.Deck {
+	if r.ShortDeck {
+		return deck.NewDeck(deck.ShortDeck)
+	}
+	return deck.NewDeck()
+}
+
+var (
+	// Texas Hold'em: two hole cards, the best five of seven play
+	Holdem Variant = &Rules{Name: "Texas Hold'em", Deal: boardStreets(2), BettingLimit: NoLimit, SmallBets: 2, High: hand.High}
+	// Omaha: four or more hole cards, exactly two of them play with three from the board
+	Omaha Variant = &Rules{Name: "Omaha", Deal: boardStreets(4), BettingLimit: PotLimit, SmallBets: 2, High: hand.OmahaHigh}
+	// Omaha eight-or-better: pots are split between the best high and the best low
+	OmahaHiLo Variant = &Rules{Name: "Omaha Hi/Lo", Deal: boardStreets(4), BettingLimit: PotLimit, SmallBets: 2, High: hand.OmahaHigh, Low: hand.OmahaEightOrBetter}
+	// Seven-Card Stud: each player's own up and down cards, no board
+	SevenCardStud Variant = &Rules{Name: "Seven-Card Stud", Deal: studStreets(), BettingLimit: FixedLimit, SmallBets: 2, High: hand.High, Forced: BringInLow}
+	// Seven-Card Stud eight-or-better
+	SevenCardStudHiLo Variant = &Rules{Name: "Seven-Card Stud Hi/Lo", Deal: studStreets(), BettingLimit: FixedLimit, SmallBets: 2, High: hand.High, Low: hand.EightOrBetter, Forced: BringInLow}
+	// Razz: dealt as Seven-Card Stud, the best ace-to-five low wins
+	Razz Variant = &Rules{Name: "Razz", Deal: studStreets(), BettingLimit: FixedLimit, SmallBets: 2, High: hand.AceToFive, Forced: BringInHigh}
+	// Five-Card Draw: five hole cards, one draw, the big bet after it
+	FiveCardDraw Variant = &Rules{Name: "Five-Card Draw", Deal: drawStreets(1), BettingLimit: FixedLimit, SmallBets: 1, High: hand.High}
+	// 2-7 Triple Draw: five hole cards, three draws, the lowest hand wins
+	DeuceSevenTripleDraw Variant = &Rules{Name: "2-7 Triple Draw", Deal: drawStreets(3), BettingLimit: FixedLimit, SmallBets: 2, High: hand.DeuceToSeven}
+	// Short-deck (6+) Hold'em: a 36-card deck, flushes beating full houses
+	ShortDeckHoldem Variant = NewShortDeckVariant(false)
+)
+
+// NewShortDeckVariant returns short-deck Hold'em, with three of a kind
+// ranking above a straight if tripsBeatStraight is set.
+func NewShortDeckVariant(tripsBeatStraight bool) Variant {
+	return &Rules{
+		Name:         "Short-Deck Hold'em",
+		Deal:         boardStreets(2),
+		BettingLimit: NoLimit,
+		SmallBets:    2,
+		High:         hand.ShortDeck{TripsBeatStraight: tripsBeatStraight},
+		ShortDeck:    true,
+	}
+}
+
+// boardStreets returns the deal of a flop game: hole cards, then the flop,
+// turn and river.
+func boardStreets(holeCards int) []Street {
+	return []Street{{Down: holeCards}, {Board: 3}, {Board: 1}, {Board: 1}}
+}
+
+// studStreets returns the deal of seven-card stud: two down cards and one
+// up on third street, an up card on each of the next three and a down card
+// on seventh.
+func studStreets() []Street {
+	return []Street{{Down: 2, Up: 1}, {Up: 1}, {Up: 1}, {Up: 1}, {Down: 1}}
+}
+
+// drawStreets returns the deal of a draw game: five hole cards and then the
+// given number of draws.
+func drawStreets(draws int) []Street {
+	streets := []Street{{Down: 5}}
+	for i := 0; i < draws; i++ {
+		streets = append(streets, Street{Draw: true})
+	}
+	return streets
+}
+
+// NewVariantGame initializes a game of the given variant with its usual
+// betting limit and deck.
+func NewVariantGame(v Variant, players []*player.Player, smallBlind, bigBlind int) *Game {
+	g := newGame(players, smallBlind, bigBlind)
+	g.Variant = v
+	g.Limit = v.Limit()
+	g.newDeck()
+	g.startingChips = g.ChipTotal()
+	return g
+}
+
+// NewOmahaGame initializes a pot-limit Omaha game dealing four, five or six
+// hole cards. It returns an error for any other number.
+func NewOmahaGame(players []*player.Player, smallBlind, bigBlind, holeCards int) (*Game, error) {
+	return newOmahaGame(Omaha, players, smallBlind, bigBlind, holeCards)
+}
+
+// NewOmahaHiLoGame initializes a pot-limit Omaha eight-or-better game
+// dealing four, five or six hole cards. It returns an error for any other
+// number.
+func NewOmahaHiLoGame(players []*player.Player, smallBlind, bigBlind, holeCards int) (*Game, error) {
+	return newOmahaGame(OmahaHiLo, players, smallBlind, bigBlind, holeCards)
+}
+
+func newOmahaGame(v Variant, players []*player.Player, smallBlind, bigBlind, holeCards int) (*Game, error) {
+	if holeCards < 4 || holeCards > 6 {
+		return nil, fmt.Errorf("%s is dealt four, five or six hole cards, not %d", v, holeCards)
+	}
+	g := NewVariantGame(v, players, smallBlind, bigBlind)
+	g.HoleCards = holeCards
+	return g, nil
+}
+
+// evaluator returns how the variant ranks hands for the whole pot, or for
+// the high half of a split pot.
+func (g *Game) evaluator() hand.Evaluator {
+	high, _ := g.Variant.Evaluators()
+	return high
+}
+
+// lowEvaluator returns how the low half of a split pot is ranked, or nil if
+// the variant does not split pots.
+func (g *Game) lowEvaluator() hand.Evaluator {
+	_, low := g.Variant.Evaluators()
+	return low
+}
+
+// boardSize returns the number of community cards the variant deals.
+func (g *Game) boardSize() int {
+	size := 0
+	for _, street := range g.Variant.Streets() {
+		size += street.Board
+	}
+	return size
+}
+
+// MaxAmount returns the most chips p may put in with their next action. In
+// pot-limit a player may call and then raise by the size of the pot
+// including the call. In fixed-limit a player may raise by one bet, or
+// complete a bring-in to a full bet, until the round is capped.
+func (g *Game) MaxAmount(p *player.Player) int {
+	toCall := g.CurrentBet - p.Bet
+	max := p.Stack
+	switch g.Limit {
+	case PotLimit:
+		max = toCall + g.Pot.Chips + toCall
+	case FixedLimit:
+		max = toCall
+		if g.bets < g.betCap() {
+			max = g.raiseTo() - p.Bet
+		}
+	}
+	if max > p.Stack {
+		return p.Stack
+	}
+	return max
+}
+
+// betCap returns the number of bets and raises allowed on each fixed-limit
+// betting round.
+func (g *Game) betCap() int {
+	if g.MaxBets > 0 {
+		return g.MaxBets
+	}
+	return maxBets
+}
+
+// raiseTo returns the bet a fixed-limit raise makes on the current round.
+func (g *Game) raiseTo() int {
+	if g.CurrentBet < g.betSize() {
+		return g.betSize()
+	}
+	return g.CurrentBet + g.betSize()
+}
+
+// betSize returns the fixed-limit bet for the current round: the small bet
+// on the variant's early rounds and the big bet afterwards. Without bet
+// sizes the big blind is the small bet and twice it the big bet.
+func (g *Game) betSize() int {
+	smallBet, bigBet := g.SmallBet, g.BigBet
+	if smallBet == 0 {
+		smallBet, bigBet = g.BigBlind, 2*g.BigBlind
+	}
+	if g.BettingRound < g.Variant.SmallBetRounds() {
+		return smallBet
+	}
+	return bigBet
+}
+
+// validateAmount checks the chips p puts in with an action against the
+// betting limit. A call must be exactly the amount to call. A fixed-limit
+// bet or raise must be exactly one bet unless it puts the player all-in,
+// and none may be made once the round is capped; any other bet or raise
+// must be at least the minimum unless it puts the player all-in.
+func (g *Game) validateAmount(p *player.Player, a *action.Action) error {
+	toCall := g.toCall(p)
+	if a.Type == action.Call && a.Amount != toCall {
+		return fmt.Errorf("player %s must put in exactly %d to call, not %d", p.Name, toCall, a.Amount)
+	}
+	raising := a.Type == action.Bet || a.Type == action.Raise
+	if g.Limit == FixedLimit && raising && g.bets >= g.betCap() {
+		return fmt.Errorf("betting is capped at %d bets on this round", g.betCap())
+	}
+	max := g.MaxAmount(p)
+	if a.Amount > max {
+		return fmt.Errorf("player %s may put in at most %d, not %d", p.Name, max, a.Amount)
+	}
+	if g.Limit == FixedLimit && raising && a.Amount != max && a.Amount != p.Stack {
+		return fmt.Errorf("player %s must put in exactly %d to %s", p.Name, max, strings.ToLower(string(a.Type)))
+	}
+	if minimum := min(toCall+g.minRaise(), max); raising && a.Amount < minimum && a.Amount != p.Stack {
+		return fmt.Errorf("player %s must put in at least %d to %s, not %d", p.Name, minimum, strings.ToLower(string(a.Type)), a.Amount)
+	}
+	return nil
+}
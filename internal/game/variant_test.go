@@ -0,0 +1,228 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prfc0/aksha/internal/action"
+	"github.com/prfc0/aksha/internal/card"
+	"github.com/prfc0/aksha/internal/hand"
+	"github.com/prfc0/aksha/internal/player"
+)
+
+func TestOmahaDeal(t *testing.T) {
+	for _, holeCards := range []int{4, 5, 6} {
+		players := []*player.Player{
+			player.NewPlayer("1", "Alice", 1000),
+			player.NewPlayer("2", "Bob", 1000),
+			player.NewPlayer("3", "Charlie", 1000),
+		}
+		game, err := NewOmahaGame(players, 10, 20, holeCards)
+		if err != nil {
+			t.Fatalf("Unexpected error creating a %d-card Omaha game: %v", holeCards, err)
+		}
+		game.StartHand()
+
+		for _, player := range game.Players {
+			if len(player.Hand) != holeCards {
+				t.Errorf("Expected %d hole cards, got %d", holeCards, len(player.Hand))
+			}
+		}
+	}
+}
+
+func TestOmahaHoleCards(t *testing.T) {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+	}
+	for _, holeCards := range []int{0, 2, 3, 7, 9} {
+		if _, err := NewOmahaGame(players, 10, 20, holeCards); err == nil {
+			t.Errorf("Expected error dealing %d hole cards in Omaha", holeCards)
+		}
+		if _, err := NewOmahaHiLoGame(players, 10, 20, holeCards); err == nil {
+			t.Errorf("Expected error dealing %d hole cards in Omaha Hi/Lo", holeCards)
+		}
+	}
+
+	// Six cards each to eight players and a board need 53 cards
+	players = make([]*player.Player, 8)
+	for i := range players {
+		players[i] = player.NewPlayer(fmt.Sprint(i), fmt.Sprint("Player ", i), 1000)
+	}
+	game, _ := NewOmahaGame(players, 10, 20, 6)
+	if err := game.StartHand(); err == nil {
+		t.Error("Expected error starting a hand the deck cannot deal")
+	}
+	if game.Pot.Chips != 0 {
+		t.Errorf("Expected no blinds to be posted, got a pot of %d", game.Pot.Chips)
+	}
+}
+
+func TestOmahaShowdown(t *testing.T) {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+	}
+	game, _ := NewOmahaGame(players, 10, 20, 4)
+	game.CommunityCards = []*card.Card{
+		card.NewCard(card.Spades, card.Ace),
+		card.NewCard(card.Spades, card.King),
+		card.NewCard(card.Spades, card.Queen),
+		card.NewCard(card.Hearts, card.Seven),
+		card.NewCard(card.Diamonds, card.Two),
+	}
+	// Alice has one spade and no flush; Bob's pair of sevens makes a set
+	players[0].Hand = []*card.Card{
+		card.NewCard(card.Spades, card.Jack),
+		card.NewCard(card.Clubs, card.Three),
+		card.NewCard(card.Clubs, card.Four),
+		card.NewCard(card.Diamonds, card.Five),
+	}
+	players[1].Hand = []*card.Card{
+		card.NewCard(card.Clubs, card.Seven),
+		card.NewCard(card.Diamonds, card.Seven),
+		card.NewCard(card.Clubs, card.Eight),
+		card.NewCard(card.Hearts, card.Nine),
+	}
+
+	winners := game.DetermineWinner()
+	if len(winners) != 1 || winners[0].Name != "Bob" {
+		t.Errorf("Expected Bob to win, got %v", winners)
+	}
+}
+
+func TestPotLimit(t *testing.T) {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+	}
+	game, _ := NewOmahaGame(players, 10, 20, 4)
+	game.StartHand()
+	alice, bob := players[0], players[1]
+
+	// Alice calls 10 and raises the 40 in the pot: 50 in, to 60
+	if game.MaxAmount(alice) != 50 {
+		t.Errorf("Expected Alice to put in at most 50, got %d", game.MaxAmount(alice))
+	}
+	if err := game.PerformAction(alice, action.NewAction(action.Raise, 60)); err == nil {
+		t.Error("Expected error raising more than the pot")
+	}
+	if err := game.PerformAction(alice, action.NewAction(action.Raise, 50)); err != nil {
+		t.Errorf("Unexpected error making a pot-sized raise: %v", err)
+	}
+
+	// Bob calls 40 and raises the 120 in the pot: 160 in, to 180
+	if game.MaxAmount(bob) != 160 {
+		t.Errorf("Expected Bob to put in at most 160, got %d", game.MaxAmount(bob))
+	}
+
+	game.Limit = NoLimit
+	if game.MaxAmount(bob) != 980 {
+		t.Errorf("Expected Bob to put in the whole stack in no-limit, got %d", game.MaxAmount(bob))
+	}
+}
+
+func TestOmahaHiLoSplitPot(t *testing.T) {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+	}
+	game, _ := NewOmahaHiLoGame(players, 10, 20, 4)
+	game.Pot.AddChips(101)
+	game.Muck = func(p *player.Player) bool { return true }
+	game.CommunityCards = []*card.Card{
+		card.NewCard(card.Spades, card.Two),
+		card.NewCard(card.Hearts, card.Four),
+		card.NewCard(card.Clubs, card.Seven),
+		card.NewCard(card.Diamonds, card.King),
+		card.NewCard(card.Hearts, card.King),
+	}
+	// Alice makes four kings; Bob's ace-three makes the only low
+	players[0].Hand = []*card.Card{
+		card.NewCard(card.Spades, card.King),
+		card.NewCard(card.Clubs, card.King),
+		card.NewCard(card.Spades, card.Queen),
+		card.NewCard(card.Diamonds, card.Queen),
+	}
+	players[1].Hand = []*card.Card{
+		card.NewCard(card.Spades, card.Ace),
+		card.NewCard(card.Clubs, card.Three),
+		card.NewCard(card.Diamonds, card.Nine),
+		card.NewCard(card.Diamonds, card.Ten),
+	}
+
+	game.EndHand()
+	if players[0].Stack != 1051 || players[1].Stack != 1050 {
+		t.Errorf("Expected the pot split 51/50 with the odd chip to the high, got %d/%d", players[0].Stack, players[1].Stack)
+	}
+	if len(game.Shown["2"]) != 4 {
+		t.Error("Expected the winning low to be shown rather than mucked")
+	}
+}
+
+func TestDealStreet(t *testing.T) {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+	}
+	game := NewGame(players, 10, 20)
+	game.StartHand()
+
+	for _, want := range []int{3, 4, 5, 5} {
+		game.DealStreet()
+		if len(game.CommunityCards) != want {
+			t.Errorf("Expected %d community cards, got %d", want, len(game.CommunityCards))
+		}
+	}
+	if game.BettingRound != 3 {
+		t.Errorf("Expected no betting round after the river, got round %d", game.BettingRound)
+	}
+}
+
+func TestCustomVariant(t *testing.T) {
+	// Three hole cards, a four-card flop and a river, won by the best low
+	lowball := &Rules{
+		Name:         "Three-Card Lowball",
+		Deal:         []Street{{Down: 3}, {Board: 4}, {Board: 1}},
+		BettingLimit: FixedLimit,
+		SmallBets:    1,
+		High:         hand.AceToFive,
+	}
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+	}
+	game := NewVariantGame(lowball, players, 10, 20)
+	game.StartHand()
+	if len(players[0].Hand) != 3 || game.Limit != FixedLimit {
+		t.Fatalf("Expected three hole cards in fixed-limit, got %d", len(players[0].Hand))
+	}
+
+	game.DealStreet()
+	if len(game.CommunityCards) != 4 || game.MaxAmount(game.ActionOrder()[0]) != 40 {
+		t.Errorf("Expected a four-card flop bet with the big bet, got %d cards", len(game.CommunityCards))
+	}
+
+	game.CommunityCards = []*card.Card{
+		card.NewCard(card.Spades, card.King),
+		card.NewCard(card.Hearts, card.Queen),
+		card.NewCard(card.Spades, card.Jack),
+		card.NewCard(card.Clubs, card.Four),
+		card.NewCard(card.Diamonds, card.Two),
+	}
+	players[0].Hand = []*card.Card{
+		card.NewCard(card.Hearts, card.Ace),
+		card.NewCard(card.Hearts, card.Three),
+		card.NewCard(card.Hearts, card.Five),
+	}
+	players[1].Hand = []*card.Card{
+		card.NewCard(card.Clubs, card.Ace),
+		card.NewCard(card.Clubs, card.Three),
+		card.NewCard(card.Clubs, card.Six),
+	}
+	winners := game.DetermineWinner()
+	if len(winners) != 1 || winners[0].Name != "Alice" {
+		t.Errorf("Expected Alice's wheel to win the low, got %v", winners)
+	}
+}
@@ -0,0 +1,163 @@
+package game
+
+import (
+	"github.com/prfc0/aksha/internal/action"
+	"github.com/prfc0/aksha/internal/card"
+	"github.com/prfc0/aksha/internal/player"
+)
+
+// View is what one player can see of the hand, as given to whatever decides
+// their actions.
+type View struct {
+	Variant      Variant      // Game being played
+	PlayerID     string       // Player the view belongs to
+	Hand         []*card.Card // The player's own cards
+	Board        []*card.Card // Community cards
+	Pot          int          // Chips in the pot, including bets on the current round
+	CurrentBet   int          // Bet to match on the current round
+	ToCall       int          // Chips the player needs to call, capped at their stack
+	Stack        int          // The player's chip stack
+	BigBlind     int          // Big blind, or the smallest bet in games without blinds
+	BettingRound int          // Current betting round, counting from the opening deal
+	Drawing      bool         // Whether the player is to draw before betting
+	History      []Move       // Actions taken in the hand so far, in order; cards others discard are nil
+	Players      []SeatView   // Every player in the hand, the viewer included
+}
+
+// SeatView is what a player can see of another player.
+type SeatView struct {
+	ID     string       // Unique identifier for the player
+	Name   string       // Name of the player
+	Stack  int          // Chip stack
+	Bet    int          // Chips committed on the current betting round
+	Active bool         // Whether the player is still in the hand
+	AllIn  bool         // Whether the player is all-in
+	Hand   []*card.Card // Cards the viewer may see; hidden cards are nil
+}
+
+// Choice is an action a player may take and the chips they may put in with
+// it. A check is a Call of 0.
+type Choice struct {
+	Type action.ActionType // Type of action
+	Min  int               // Fewest chips the action puts in
+	Max  int               // Most chips the action puts in
+}
+
+// View returns the hand as p sees it. The view holds copies, so whatever
+// reads it cannot change the hand.
+func (g *Game) View(p *player.Player) *View {
+	view := &View{
+		Variant:      g.Variant,
+		PlayerID:     p.ID,
+		Hand:         append([]*card.Card{}, p.Hand...),
+		Board:        append([]*card.Card{}, g.CommunityCards...),
+		Pot:          g.Pot.Chips,
+		CurrentBet:   g.CurrentBet,
+		ToCall:       g.toCall(p),
+		Stack:        p.Stack,
+		BigBlind:     g.minBet(),
+		BettingRound: g.BettingRound,
+		Drawing:      g.drawing(p),
+		History:      g.visibleHistory(p.ID),
+		Players:      make([]SeatView, 0, len(g.dealt)),
+	}
+	for _, player := range g.dealt {
+		view.Players = append(view.Players, SeatView{
+			ID:     player.ID,
+			Name:   player.Name,
+			Stack:  player.Stack,
+			Bet:    player.Bet,
+			Active: player.Active,
+			AllIn:  player.AllIn,
+			Hand:   g.VisibleHand(p.ID, player),
+		})
+	}
+	return view
+}
+
+// visibleHistory returns a copy of the hand's history as the player with
+// the given ID sees it: other players' draws show how many cards they
+// discarded but not which.
+func (g *Game) visibleHistory(viewerID string) []Move {
+	history := make([]Move, len(g.History))
+	for i, move := range g.History {
+		visible := *move.Action
+		visible.Cards = append([]*card.Card{}, move.Action.Cards...)
+		if move.PlayerID != viewerID {
+			visible.Cards = make([]*card.Card, len(move.Action.Cards))
+		}
+		move.Action = &visible
+		history[i] = move
+	}
+	return history
+}
+
+// LegalActions returns the actions p may take now. A player who is to draw
+// may only draw; otherwise they may check or call, fold if facing a bet,
+// and bet or raise while the betting limit allows. In no-limit and
+// pot-limit a bet is at least the big blind, and a raise is at least the
+// size of the largest bet or raise before it on the round.
+func (g *Game) LegalActions(p *player.Player) []Choice {
+	if !p.Active || p.AllIn {
+		return nil
+	}
+	if g.drawing(p) {
+		return []Choice{{Type: action.Draw}}
+	}
+
+	toCall := g.toCall(p)
+	choices := make([]Choice, 0, 3)
+	if toCall > 0 {
+		choices = append(choices, Choice{Type: action.Fold})
+	}
+	choices = append(choices, Choice{Type: action.Call, Min: toCall, Max: toCall})
+
+	max := g.MaxAmount(p)
+	if max <= toCall {
+		return choices
+	}
+	raise := Choice{Type: action.Raise, Min: toCall + g.minRaise(), Max: max}
+	if g.CurrentBet == 0 {
+		raise.Type = action.Bet
+	}
+	if g.Limit == FixedLimit || raise.Min > max {
+		raise.Min = max
+	}
+	return append(choices, raise)
+}
+
+// toCall returns the chips p needs to call the current bet, capped at
+// their stack.
+func (g *Game) toCall(p *player.Player) int {
+	toCall := g.CurrentBet - p.Bet
+	if toCall > p.Stack {
+		return p.Stack
+	}
+	return toCall
+}
+
+// minBet returns the smallest bet: the big blind, the button ante in
+// button-ante games, or the fixed-limit bet of the current round in other
+// games without blinds.
+func (g *Game) minBet() int {
+	switch {
+	case g.BigBlind > 0:
+		return g.BigBlind
+	case g.ButtonAnte > 0:
+		return g.ButtonAnte
+	}
+	return g.betSize()
+}
+
+// minRaise returns the least a bet or raise may add to the current bet: the
+// size of the largest bet or raise before it on the round, and at least
+// the smallest bet.
+func (g *Game) minRaise() int {
+	return max(g.lastRaise, g.minBet())
+}
+
+// drawing reports whether p has yet to draw in a draw in progress.
+func (g *Game) drawing(p *player.Player) bool {
+	streets := g.Variant.Streets()
+	return p.Active && g.BettingRound < len(streets) && streets[g.BettingRound].Draw && !g.drawn[p.ID]
+}
@@ -0,0 +1,151 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/prfc0/aksha/internal/action"
+	"github.com/prfc0/aksha/internal/card"
+	"github.com/prfc0/aksha/internal/player"
+)
+
+func TestLegalActions(t *testing.T) {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+		player.NewPlayer("3", "Charlie", 15),
+	}
+	game := NewGame(players, 5, 10)
+	game.StartHand()
+	alice, bob, charlie := game.Players[0], game.Players[1], game.Players[2]
+
+	tests := []struct {
+		name     string
+		player   *player.Player
+		expected []Choice
+	}{
+		{"under the gun", alice, []Choice{{action.Fold, 0, 0}, {action.Call, 10, 10}, {action.Raise, 20, 1000}}},
+		{"small blind", bob, []Choice{{action.Fold, 0, 0}, {action.Call, 5, 5}, {action.Raise, 15, 995}}},
+		{"short big blind", charlie, []Choice{{action.Call, 0, 0}, {action.Raise, 5, 5}}},
+	}
+	for _, tt := range tests {
+		if choices := game.LegalActions(tt.player); !reflect.DeepEqual(choices, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, choices)
+		}
+	}
+
+	alice.Fold()
+	if choices := game.LegalActions(alice); choices != nil {
+		t.Errorf("Expected no actions after folding, got %v", choices)
+	}
+}
+
+func TestLegalActionsFixedLimitAndDraw(t *testing.T) {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+	}
+	game := NewTripleDrawGame(players, 10, 20)
+	game.StartHand()
+	button := game.ActionOrder()[0]
+
+	expected := []Choice{{action.Fold, 0, 0}, {action.Call, 10, 10}, {action.Raise, 30, 30}}
+	if choices := game.LegalActions(button); !reflect.DeepEqual(choices, expected) {
+		t.Errorf("Expected a fixed raise, got %v", choices)
+	}
+
+	game.DealStreet()
+	if choices := game.LegalActions(button); len(choices) != 1 || choices[0].Type != action.Draw {
+		t.Errorf("Expected to draw first, got %v", choices)
+	}
+	if !game.View(button).Drawing {
+		t.Error("Expected the view to show the player is drawing")
+	}
+	game.PerformAction(button, action.NewDraw(nil))
+	expected = []Choice{{action.Call, 0, 0}, {action.Bet, 20, 20}}
+	if choices := game.LegalActions(button); !reflect.DeepEqual(choices, expected) {
+		t.Errorf("Expected to check or bet after drawing, got %v", choices)
+	}
+}
+
+func TestView(t *testing.T) {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+		player.NewPlayer("3", "Charlie", 1000),
+	}
+	game := NewGame(players, 5, 10)
+	game.StartHand()
+	alice := game.Players[0]
+
+	game.PerformAction(game.Players[1], action.NewAction(action.Call, 5))
+	view := game.View(alice)
+	if len(view.History) != 1 || view.History[0].PlayerID != "2" || view.History[0].Action.Type != action.Call {
+		t.Errorf("Expected Bob's call in the history, got %v", view.History)
+	}
+	if view.ToCall != 10 || view.Pot != 20 || view.BigBlind != 10 || len(view.Hand) != 2 {
+		t.Errorf("Unexpected view: %+v", view)
+	}
+	for _, seat := range view.Players {
+		hidden := seat.Hand[0] == nil && seat.Hand[1] == nil
+		if hidden == (seat.ID == alice.ID) {
+			t.Errorf("Expected Alice to see only her own cards, got %v for %s", seat.Hand, seat.Name)
+		}
+	}
+}
+
+func TestViewHidesDiscards(t *testing.T) {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+	}
+	game := NewTripleDrawGame(players, 10, 20)
+	game.StartHand()
+	game.DealStreet()
+	alice, bob := game.Players[0], game.Players[1]
+	discards := []*card.Card{alice.Hand[0], alice.Hand[1]}
+	game.PerformAction(alice, action.NewDraw(discards))
+
+	draw := game.View(bob).History[0].Action
+	if draw.Type != action.Draw || len(draw.Cards) != 2 || draw.Cards[0] != nil || draw.Cards[1] != nil {
+		t.Errorf("Expected Bob to see only that Alice drew two, got %v", draw.Cards)
+	}
+	if own := game.View(alice).History[0].Action; own.Cards[0] != discards[0] || own.Cards[1] != discards[1] {
+		t.Errorf("Expected Alice to see her own discards, got %v", own.Cards)
+	}
+
+	// Changing a view leaves the hand alone
+	view := game.View(alice)
+	view.Hand[0] = nil
+	view.History[0].Action.Cards[0] = nil
+	if alice.Hand[0] == nil || game.History[0].Action.Cards[0] == nil {
+		t.Error("Expected the view to hold copies of the hand")
+	}
+}
+
+func TestLegalReRaise(t *testing.T) {
+	players := []*player.Player{
+		player.NewPlayer("1", "Alice", 1000),
+		player.NewPlayer("2", "Bob", 1000),
+		player.NewPlayer("3", "Charlie", 1000),
+	}
+	game := NewGame(players, 10, 20)
+	game.StartHand()
+	order := game.ActionOrder()
+
+	// A raise from 20 to 100 makes the smallest re-raise one to 180
+	if err := game.PerformAction(order[0], action.NewAction(action.Raise, 100)); err != nil {
+		t.Fatalf("Unexpected error raising: %v", err)
+	}
+	bigBlind := order[2]
+	expected := []Choice{{action.Fold, 0, 0}, {action.Call, 80, 80}, {action.Raise, 160, 980}}
+	if choices := game.LegalActions(bigBlind); !reflect.DeepEqual(choices, expected) {
+		t.Errorf("Expected a minimum re-raise to 180, got %v", choices)
+	}
+
+	// After the flop the minimum bet is the big blind again
+	game.DealStreet()
+	if choices := game.LegalActions(bigBlind); choices[1].Type != action.Bet || choices[1].Min != 20 {
+		t.Errorf("Expected a minimum bet of 20 on the flop, got %v", choices)
+	}
+}
@@ -0,0 +1,94 @@
+package hand
+
+import (
+	"github.com/prfc0/aksha/internal/card"
+)
+
+// Ranked is a hand ranked by an Evaluator, either a *Hand or a *Low.
+type Ranked interface {
+	String() string
+}
+
+// Evaluator ranks the hands players make for a pot, or for one half of a
+// split pot.
+type Evaluator interface {
+	// Evaluate returns the best hand that can be made from the hole cards
+	// and board, or nil if none qualifies.
+	Evaluate(hole, board []*card.Card) Ranked
+	// Compare compares two hands from Evaluate and returns 1 if a wins, 0
+	// if they tie and -1 if b wins.
+	Compare(a, b Ranked) int
+}
+
+var (
+	High               Evaluator = highEvaluator{}                     // Best five-card high hand
+	OmahaHigh          Evaluator = highEvaluator{omaha: true}          // Best high hand using exactly two hole cards
+	EightOrBetter      Evaluator = eightOrBetterEvaluator{}            // Best eight-or-better low, if any
+	OmahaEightOrBetter Evaluator = eightOrBetterEvaluator{omaha: true} // Best eight-or-better low using exactly two hole cards
+	AceToFive          Evaluator = aceToFiveEvaluator{}                // Best ace-to-five low, as in Razz
+	DeuceToSeven       Evaluator = deuceToSevenEvaluator{}             // Best deuce-to-seven low
+)
+
+type highEvaluator struct {
+	omaha bool
+}
+
+func (e highEvaluator) Evaluate(hole, board []*card.Card) Ranked {
+	if e.omaha {
+		return OmahaBestHand(hole, board)
+	}
+	return BestHand(join(hole, board))
+}
+
+func (e highEvaluator) Compare(a, b Ranked) int {
+	return a.(*Hand).Compare(b.(*Hand))
+}
+
+type eightOrBetterEvaluator struct {
+	omaha bool
+}
+
+func (e eightOrBetterEvaluator) Evaluate(hole, board []*card.Card) Ranked {
+	var low *Low
+	if e.omaha {
+		low = OmahaBestLow(hole, board)
+	} else {
+		low = BestLow(join(hole, board))
+	}
+	// A nil *Low would not compare equal to a nil Ranked
+	if low == nil {
+		return nil
+	}
+	return low
+}
+
+func (e eightOrBetterEvaluator) Compare(a, b Ranked) int {
+	return a.(*Low).Compare(b.(*Low))
+}
+
+type aceToFiveEvaluator struct{}
+
+func (e aceToFiveEvaluator) Evaluate(hole, board []*card.Card) Ranked {
+	return BestAceFiveLow(join(hole, board))
+}
+
+func (e aceToFiveEvaluator) Compare(a, b Ranked) int {
+	return a.(*Low).Compare(b.(*Low))
+}
+
+type deuceToSevenEvaluator struct{}
+
+func (e deuceToSevenEvaluator) Evaluate(hole, board []*card.Card) Ranked {
+	return BestDeuceSevenHand(join(hole, board))
+}
+
+func (e deuceToSevenEvaluator) Compare(a, b Ranked) int {
+	return a.(*Hand).CompareDeuceSeven(b.(*Hand))
+}
+
+// join returns the hole cards and board as a single new slice.
+func join(hole, board []*card.Card) []*card.Card {
+	cards := make([]*card.Card, 0, len(hole)+len(board))
+	cards = append(cards, hole...)
+	return append(cards, board...)
+}
@@ -0,0 +1,42 @@
+package hand
+
+import (
+	"testing"
+
+	"github.com/prfc0/aksha/internal/card"
+)
+
+func TestEvaluators(t *testing.T) {
+	board := []*card.Card{
+		card.NewCard(card.Spades, card.Two),
+		card.NewCard(card.Hearts, card.Three),
+		card.NewCard(card.Clubs, card.Four),
+		card.NewCard(card.Diamonds, card.King),
+		card.NewCard(card.Hearts, card.Nine),
+	}
+	wheel := []*card.Card{card.NewCard(card.Spades, card.Ace), card.NewCard(card.Clubs, card.Five)}
+	sevenSix := []*card.Card{card.NewCard(card.Spades, card.Seven), card.NewCard(card.Clubs, card.Six)}
+
+	tests := []struct {
+		name      string
+		evaluator Evaluator
+		want      int
+	}{
+		{"the wheel straight wins high", High, 1},
+		{"the wheel wins ace-to-five", AceToFive, 1},
+		{"the wheel wins eight-or-better", EightOrBetter, 1},
+		{"seven-six wins deuce-to-seven, where aces are high", DeuceToSeven, -1},
+	}
+	for _, test := range tests {
+		a := test.evaluator.Evaluate(wheel, board)
+		b := test.evaluator.Evaluate(sevenSix, board)
+		if got := test.evaluator.Compare(a, b); got != test.want {
+			t.Errorf("Expected %s, got %d", test.name, got)
+		}
+	}
+
+	kings := []*card.Card{card.NewCard(card.Spades, card.King), card.NewCard(card.Clubs, card.Queen)}
+	if low := EightOrBetter.Evaluate(kings, board[2:]); low != nil {
+		t.Errorf("Expected no qualifying low, got %v", low)
+	}
+}
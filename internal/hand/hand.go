@@ -2,7 +2,6 @@ package hand
 
 import (
 	"fmt"
-	"sort"
 
 	"github.com/prfc0/aksha/internal/card"
 )
@@ -28,6 +27,9 @@ type Hand struct {
 	Rank HandRank
 	// For comparison (e.g. Straight vs Straight, Flush vs Flush)
 	Strength []int
+
+	acesHigh  bool       // Whether A-2-3-4-5 is not a straight, as in deuce-to-seven
+	shortDeck *ShortDeck // Short-deck rules the hand is ranked by, if any
 }
 
 func NewHand(cards []*card.Card) *Hand {
@@ -40,21 +42,91 @@ func NewHand(cards []*card.Card) *Hand {
 	return hand
 }
 
-func (h *Hand) evaluate() {
-	sort.Slice(h.Cards, func(i, j int) bool {
-		return h.Cards[i].Value() > h.Cards[j].Value()
+// BestHand returns the strongest five-card hand that can be made from cards.
+// Fewer than five cards are evaluated as they are.
+func BestHand(cards []*card.Card) *Hand {
+	if len(cards) <= 5 {
+		return NewHand(append([]*card.Card{}, cards...))
+	}
+
+	var best *Hand
+	combinations(len(cards), 5, func(combination []int) {
+		selected := make([]*card.Card, 5)
+		for i, index := range combination {
+			selected[i] = cards[index]
+		}
+		candidate := NewHand(selected)
+		if best == nil || candidate.Compare(best) > 0 {
+			best = candidate
+		}
 	})
+	return best
+}
+
+// OmahaBestHand returns the strongest hand made from exactly two hole cards
+// and three board cards. Before the flop every board card is used.
+func OmahaBestHand(hole, board []*card.Card) *Hand {
+	boardCards := 3
+	if len(board) < boardCards {
+		boardCards = len(board)
+	}
+
+	var best *Hand
+	combinations(len(hole), 2, func(holeCombination []int) {
+		combinations(len(board), boardCards, func(boardCombination []int) {
+			selected := make([]*card.Card, 0, 5)
+			for _, index := range holeCombination {
+				selected = append(selected, hole[index])
+			}
+			for _, index := range boardCombination {
+				selected = append(selected, board[index])
+			}
+			candidate := NewHand(selected)
+			if best == nil || candidate.Compare(best) > 0 {
+				best = candidate
+			}
+		})
+	})
+	return best
+}
+
+// combinations calls fn with the indexes of every way to choose k of n
+// items, in increasing order.
+func combinations(n, k int, fn func([]int)) {
+	combination := make([]int, k)
+	var choose func(start, depth int)
+	choose = func(start, depth int) {
+		if depth == k {
+			fn(combination)
+			return
+		}
+		for i := start; i <= n-(k-depth); i++ {
+			combination[depth] = i
+			choose(i+1, depth+1)
+		}
+	}
+	choose(0, 0)
+}
+
+func (h *Hand) evaluate() {
+	// Insertion sort, highest first: hands are small and evaluated by the
+	// million, where sort.Slice's overhead shows
+	for i := 1; i < len(h.Cards); i++ {
+		for j := i; j > 0 && h.Cards[j].Value() > h.Cards[j-1].Value(); j-- {
+			h.Cards[j], h.Cards[j-1] = h.Cards[j-1], h.Cards[j]
+		}
+	}
 
 	isFlush := h.isFlush()
 	isStraight := h.isStraight()
 
-	if isFlush && isStraight && h.Cards[0].Rank == card.Ace {
-		if h.Cards[0].Rank == card.Ace {
+	if isFlush && isStraight {
+		h.Strength = []int{h.straightHigh()}
+		if h.Strength[0] == int(card.Ace) {
 			h.Rank = RoyalFlush
 		} else {
 			h.Rank = StraightFlush
 		}
-		h.Strength = []int{h.Cards[0].Value()}
 		return
 	}
 
@@ -78,7 +150,7 @@ func (h *Hand) evaluate() {
 
 	if isStraight {
 		h.Rank = Straight
-		h.Strength = []int{h.Cards[0].Value()}
+		h.Strength = []int{h.straightHigh()}
 		return
 	}
 
@@ -105,6 +177,9 @@ func (h *Hand) evaluate() {
 }
 
 func (h *Hand) isFlush() bool {
+	if len(h.Cards) < 5 {
+		return false
+	}
 	suit := h.Cards[0].Suit
 	for _, card := range h.Cards {
 		if card.Suit != suit {
@@ -115,6 +190,9 @@ func (h *Hand) isFlush() bool {
 }
 
 func (h *Hand) isStraight() bool {
+	if len(h.Cards) < 5 {
+		return false
+	}
 	isRegularStraight := true
 	for i := 0; i < len(h.Cards)-1; i++ {
 		if h.Cards[i].Value() != h.Cards[i+1].Value()+1 {
@@ -125,16 +203,18 @@ func (h *Hand) isStraight() bool {
 	if isRegularStraight {
 		return true
 	}
+	if h.acesHigh {
+		return false
+	}
 
 	isWheelStraight := true
 	wheelRanks := []card.Rank{card.Ace, card.Two, card.Three, card.Four, card.Five}
-	rankMap := make(map[card.Rank]bool)
-	for _, card := range h.Cards {
-		rankMap[card.Rank] = true
+	if h.shortDeck != nil {
+		wheelRanks = []card.Rank{card.Ace, card.Six, card.Seven, card.Eight, card.Nine}
 	}
-
+	rankCount := h.rankCount()
 	for _, rank := range wheelRanks {
-		if !rankMap[rank] {
+		if rankCount[rank] == 0 {
 			isWheelStraight = false
 			break
 		}
@@ -143,12 +223,28 @@ func (h *Hand) isStraight() bool {
 	return isWheelStraight
 }
 
-func (h *Hand) hasNOfAKind(n int) bool {
-	rankCount := make(map[card.Rank]int)
+// straightHigh returns the top card of a straight, counting the wheel as five
+// high, or nine high in short-deck.
+func (h *Hand) straightHigh() int {
+	if h.Cards[0].Rank == card.Ace && h.Cards[1].Rank != card.King {
+		return h.Cards[1].Value()
+	}
+	return h.Cards[0].Value()
+}
+
+// rankCount returns the number of cards of each rank in the hand, indexed
+// by rank. An array is much cheaper than a map for the hand evaluations
+// simulations and bots make by the million.
+func (h *Hand) rankCount() [card.Ace + 1]int {
+	var rankCount [card.Ace + 1]int
 	for _, card := range h.Cards {
 		rankCount[card.Rank]++
 	}
-	for _, count := range rankCount {
+	return rankCount
+}
+
+func (h *Hand) hasNOfAKind(n int) bool {
+	for _, count := range h.rankCount() {
 		if count == n {
 			return true
 		}
@@ -157,20 +253,14 @@ func (h *Hand) hasNOfAKind(n int) bool {
 }
 
 func (h *Hand) getNOfAKindStrength(n int) []int {
-	rankCount := make(map[card.Rank]int)
-	for _, card := range h.Cards {
-		rankCount[card.Rank]++
-	}
-
 	strength := make([]int, 0)
-	for rank, count := range rankCount {
-		if count == n {
+	rankCount := h.rankCount()
+	for rank := card.Ace; rank >= card.Two; rank-- {
+		if rankCount[rank] == n {
 			strength = append(strength, int(rank))
 		}
 	}
-
-	sort.Sort(sort.Reverse(sort.IntSlice(strength)))
-	return strength
+	return append(strength, h.getKickers(strength...)...)
 }
 
 func (h *Hand) hasFullHouse() bool {
@@ -178,19 +268,12 @@ func (h *Hand) hasFullHouse() bool {
 }
 
 func (h *Hand) getFullHouseStrength() []int {
-	strength := make([]int, 0)
-	strength = append(strength, h.getNOfAKindStrength(3)...)
-	strength = append(strength, h.getNOfAKindStrength(2)...)
-	return strength
+	return []int{h.getNOfAKindStrength(3)[0], h.getNOfAKindStrength(2)[0]}
 }
 
 func (h *Hand) hasTwoPair() bool {
 	pairCount := 0
-	rankCount := make(map[card.Rank]int)
-	for _, card := range h.Cards {
-		rankCount[card.Rank]++
-	}
-	for _, count := range rankCount {
+	for _, count := range h.rankCount() {
 		if count == 2 {
 			pairCount++
 		}
@@ -200,21 +283,35 @@ func (h *Hand) hasTwoPair() bool {
 
 func (h *Hand) getTwoPairStrength() []int {
 	strength := make([]int, 0)
-	rankCount := make(map[card.Rank]int)
-	for _, card := range h.Cards {
-		rankCount[card.Rank]++
-	}
-	for rank, count := range rankCount {
-		if count == 2 {
+	rankCount := h.rankCount()
+	for rank := card.Ace; rank >= card.Two; rank-- {
+		if rankCount[rank] == 2 {
 			strength = append(strength, int(rank))
 		}
 	}
-	sort.Sort(sort.Reverse(sort.IntSlice(strength)))
-	return strength
+	return append(strength, h.getKickers(strength...)...)
+}
+
+// getKickers returns the values of the cards whose rank is not in used, highest first.
+func (h *Hand) getKickers(used ...int) []int {
+	kickers := make([]int, 0, len(h.Cards))
+	for _, c := range h.Cards {
+		isUsed := false
+		for _, value := range used {
+			if c.Value() == value {
+				isUsed = true
+				break
+			}
+		}
+		if !isUsed {
+			kickers = append(kickers, c.Value())
+		}
+	}
+	return kickers
 }
 
 func (h *Hand) getHighCardStrength() []int {
-	strength := make([]int, 0)
+	strength := make([]int, 0, len(h.Cards))
 	for _, card := range h.Cards {
 		strength = append(strength, card.Value())
 	}
@@ -226,13 +323,13 @@ func (h *Hand) getHighCardStrength() []int {
 // 0 if h is equal to other,
 // 1 if h is stronger than other.
 func (h *Hand) Compare(other *Hand) int {
-	if h.Rank > other.Rank {
+	if h.order() > other.order() {
 		return 1
-	} else if h.Rank < other.Rank {
+	} else if h.order() < other.order() {
 		return -1
 	}
 
-	for i := 0; i < len(h.Strength); i++ {
+	for i := 0; i < len(h.Strength) && i < len(other.Strength); i++ {
 		if h.Strength[i] > other.Strength[i] {
 			return 1
 		} else if h.Strength[i] < other.Strength[i] {
@@ -242,6 +339,25 @@ func (h *Hand) Compare(other *Hand) int {
 	return 0
 }
 
+// order returns where the hand's rank places it when comparing hands, which
+// short-deck rules change.
+func (h *Hand) order() int {
+	if h.shortDeck == nil {
+		return int(h.Rank)
+	}
+	switch {
+	case h.Rank == Flush:
+		return int(FullHouse)
+	case h.Rank == FullHouse:
+		return int(Flush)
+	case h.Rank == ThreeOfAKind && h.shortDeck.TripsBeatStraight:
+		return int(Straight)
+	case h.Rank == Straight && h.shortDeck.TripsBeatStraight:
+		return int(ThreeOfAKind)
+	}
+	return int(h.Rank)
+}
+
 func (h *Hand) String() string {
 	return fmt.Sprintf("Hand: %v, Rank: %v, Strength: %v", h.Cards, h.Rank, h.Strength)
 }
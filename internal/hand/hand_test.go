@@ -120,3 +120,72 @@ func TestHandEvaluation(t *testing.T) {
 		}
 	}
 }
+
+func TestBestHand(t *testing.T) {
+	cards := []*card.Card{
+		card.NewCard(card.Hearts, card.Ace),
+		card.NewCard(card.Spades, card.Two),
+		card.NewCard(card.Spades, card.Three),
+		card.NewCard(card.Spades, card.Four),
+		card.NewCard(card.Spades, card.Five),
+		card.NewCard(card.Clubs, card.King),
+		card.NewCard(card.Spades, card.Six),
+	}
+
+	best := BestHand(cards)
+	if best.Rank != StraightFlush || best.Strength[0] != 6 {
+		t.Errorf("Expected six-high straight flush, got %v", best)
+	}
+	if len(cards) != 7 || cards[0].Rank != card.Ace {
+		t.Error("BestHand modified the cards it was given")
+	}
+}
+
+func TestOmahaBestHand(t *testing.T) {
+	hole := []*card.Card{
+		card.NewCard(card.Spades, card.Ten),
+		card.NewCard(card.Clubs, card.Three),
+		card.NewCard(card.Diamonds, card.Four),
+		card.NewCard(card.Hearts, card.Five),
+	}
+	board := []*card.Card{
+		card.NewCard(card.Spades, card.Ace),
+		card.NewCard(card.Spades, card.King),
+		card.NewCard(card.Spades, card.Queen),
+		card.NewCard(card.Spades, card.Jack),
+		card.NewCard(card.Hearts, card.Two),
+	}
+
+	// One spade in the hand makes no flush, and the board straight cannot
+	// be played with a single hole card
+	best := OmahaBestHand(hole, board)
+	if best.Rank != HighCard || best.Strength[0] != 14 || best.Strength[3] != 10 {
+		t.Errorf("Expected ace-king-queen-ten high, got %v", best)
+	}
+
+	hole[1] = card.NewCard(card.Spades, card.Three)
+	if best := OmahaBestHand(hole, board); best.Rank != Flush {
+		t.Errorf("Expected a flush with two spades in the hand, got %v", best)
+	}
+}
+
+func TestKickers(t *testing.T) {
+	aceKing := NewHand([]*card.Card{
+		card.NewCard(card.Spades, card.Ace),
+		card.NewCard(card.Hearts, card.Ace),
+		card.NewCard(card.Diamonds, card.King),
+		card.NewCard(card.Clubs, card.Seven),
+		card.NewCard(card.Spades, card.Two),
+	})
+	aceQueen := NewHand([]*card.Card{
+		card.NewCard(card.Diamonds, card.Ace),
+		card.NewCard(card.Clubs, card.Ace),
+		card.NewCard(card.Diamonds, card.Queen),
+		card.NewCard(card.Clubs, card.Jack),
+		card.NewCard(card.Spades, card.Nine),
+	})
+
+	if aceKing.Compare(aceQueen) != 1 {
+		t.Errorf("Expected %v to beat %v on kickers", aceKing, aceQueen)
+	}
+}
@@ -0,0 +1,164 @@
+package hand
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/prfc0/aksha/internal/card"
+)
+
+// Low is a hand ranked for the low half of a split pot or for ace-to-five
+// lowball: aces low, pairs counting against the hand, and otherwise compared
+// from the highest card down. Straights and flushes do not count against a
+// low.
+type Low struct {
+	Cards    []*card.Card
+	Rank     HandRank // Pairing of the hand; an eight-or-better low is always HighCard
+	Strength []int    // Values of paired cards then the rest, highest first, aces counting as 1
+}
+
+// NewLow evaluates five cards as an eight-or-better low and returns nil if
+// they do not qualify.
+func NewLow(cards []*card.Card) *Low {
+	if len(cards) != 5 {
+		return nil
+	}
+	strength := make([]int, 0, 5)
+	seen := make(map[int]bool)
+	for _, c := range cards {
+		value := lowValue(c)
+		if value > 8 || seen[value] {
+			return nil
+		}
+		seen[value] = true
+		strength = append(strength, value)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(strength)))
+	return &Low{Cards: cards, Strength: strength}
+}
+
+// BestLow returns the best eight-or-better low that can be made from any
+// five of cards, or nil if there is none.
+func BestLow(cards []*card.Card) *Low {
+	var best *Low
+	combinations(len(cards), 5, func(combination []int) {
+		selected := make([]*card.Card, 5)
+		for i, index := range combination {
+			selected[i] = cards[index]
+		}
+		if candidate := NewLow(selected); candidate != nil && (best == nil || candidate.Compare(best) > 0) {
+			best = candidate
+		}
+	})
+	return best
+}
+
+// OmahaBestLow returns the best eight-or-better low made from exactly two
+// hole cards and three board cards, or nil if there is none.
+func OmahaBestLow(hole, board []*card.Card) *Low {
+	var best *Low
+	combinations(len(hole), 2, func(holeCombination []int) {
+		combinations(len(board), 3, func(boardCombination []int) {
+			selected := make([]*card.Card, 0, 5)
+			for _, index := range holeCombination {
+				selected = append(selected, hole[index])
+			}
+			for _, index := range boardCombination {
+				selected = append(selected, board[index])
+			}
+			if candidate := NewLow(selected); candidate != nil && (best == nil || candidate.Compare(best) > 0) {
+				best = candidate
+			}
+		})
+	})
+	return best
+}
+
+// NewAceFiveLow evaluates up to five cards for ace-to-five lowball, as in
+// Razz. Every hand qualifies: a pair is worse than any unpaired hand, two
+// pair worse than one pair, and so on, while straights and flushes are
+// ignored. The best possible hand is 5-4-3-2-A.
+func NewAceFiveLow(cards []*card.Card) *Low {
+	counts := make(map[int]int)
+	for _, c := range cards {
+		counts[lowValue(c)]++
+	}
+	strength := make([]int, 0, len(counts))
+	for value := range counts {
+		strength = append(strength, value)
+	}
+	sort.Slice(strength, func(i, j int) bool {
+		if counts[strength[i]] != counts[strength[j]] {
+			return counts[strength[i]] > counts[strength[j]]
+		}
+		return strength[i] > strength[j]
+	})
+
+	rank := HighCard
+	switch {
+	case len(strength) == 0:
+	case counts[strength[0]] == 4:
+		rank = FourOfAKind
+	case counts[strength[0]] == 3 && len(strength) > 1 && counts[strength[1]] == 2:
+		rank = FullHouse
+	case counts[strength[0]] == 3:
+		rank = ThreeOfAKind
+	case counts[strength[0]] == 2 && len(strength) > 1 && counts[strength[1]] == 2:
+		rank = TwoPair
+	case counts[strength[0]] == 2:
+		rank = OnePair
+	}
+	return &Low{Cards: cards, Rank: rank, Strength: strength}
+}
+
+// BestAceFiveLow returns the best ace-to-five low that can be made from any
+// five of cards. Fewer than five cards are evaluated as they are.
+func BestAceFiveLow(cards []*card.Card) *Low {
+	if len(cards) <= 5 {
+		return NewAceFiveLow(append([]*card.Card{}, cards...))
+	}
+
+	var best *Low
+	combinations(len(cards), 5, func(combination []int) {
+		selected := make([]*card.Card, 5)
+		for i, index := range combination {
+			selected[i] = cards[index]
+		}
+		if candidate := NewAceFiveLow(selected); best == nil || candidate.Compare(best) > 0 {
+			best = candidate
+		}
+	})
+	return best
+}
+
+// Compare compares two lows and returns:
+// -1 if l is worse (higher) than other,
+// 0 if l is equal to other,
+// 1 if l is better (lower) than other.
+func (l *Low) Compare(other *Low) int {
+	if l.Rank < other.Rank {
+		return 1
+	} else if l.Rank > other.Rank {
+		return -1
+	}
+	for i := 0; i < len(l.Strength) && i < len(other.Strength); i++ {
+		if l.Strength[i] < other.Strength[i] {
+			return 1
+		} else if l.Strength[i] > other.Strength[i] {
+			return -1
+		}
+	}
+	return 0
+}
+
+func (l *Low) String() string {
+	return fmt.Sprintf("Low: %v, Rank: %v, Strength: %v", l.Cards, l.Rank, l.Strength)
+}
+
+// lowValue returns a card's value with aces low.
+func lowValue(c *card.Card) int {
+	if c.Rank == card.Ace {
+		return 1
+	}
+	return c.Value()
+}
@@ -0,0 +1,138 @@
+package hand
+
+import (
+	"testing"
+
+	"github.com/prfc0/aksha/internal/card"
+)
+
+func TestNewLow(t *testing.T) {
+	wheel := NewLow([]*card.Card{
+		card.NewCard(card.Spades, card.Five),
+		card.NewCard(card.Spades, card.Four),
+		card.NewCard(card.Spades, card.Three),
+		card.NewCard(card.Spades, card.Two),
+		card.NewCard(card.Spades, card.Ace),
+	})
+	eightSeven := NewLow([]*card.Card{
+		card.NewCard(card.Hearts, card.Eight),
+		card.NewCard(card.Clubs, card.Seven),
+		card.NewCard(card.Spades, card.Three),
+		card.NewCard(card.Spades, card.Two),
+		card.NewCard(card.Spades, card.Ace),
+	})
+	eightSix := NewLow([]*card.Card{
+		card.NewCard(card.Hearts, card.Eight),
+		card.NewCard(card.Clubs, card.Six),
+		card.NewCard(card.Spades, card.Five),
+		card.NewCard(card.Spades, card.Four),
+		card.NewCard(card.Spades, card.Three),
+	})
+
+	if wheel == nil || wheel.Strength[0] != 5 || wheel.Strength[4] != 1 {
+		t.Fatalf("Expected a straight flush wheel to be the best low, got %v", wheel)
+	}
+	if wheel.Compare(eightSix) != 1 || eightSix.Compare(eightSeven) != 1 || eightSeven.Compare(eightSeven) != 0 {
+		t.Error("Expected lows to compare from the highest card down")
+	}
+
+	tests := [][]*card.Card{
+		{ // Nine high
+			card.NewCard(card.Hearts, card.Nine),
+			card.NewCard(card.Clubs, card.Four),
+			card.NewCard(card.Spades, card.Three),
+			card.NewCard(card.Spades, card.Two),
+			card.NewCard(card.Spades, card.Ace),
+		},
+		{ // Paired
+			card.NewCard(card.Hearts, card.Two),
+			card.NewCard(card.Clubs, card.Four),
+			card.NewCard(card.Spades, card.Three),
+			card.NewCard(card.Spades, card.Two),
+			card.NewCard(card.Spades, card.Ace),
+		},
+	}
+	for _, cards := range tests {
+		if low := NewLow(cards); low != nil {
+			t.Errorf("Expected %v not to qualify for low", low)
+		}
+	}
+}
+
+func TestOmahaBestLow(t *testing.T) {
+	board := []*card.Card{
+		card.NewCard(card.Spades, card.Two),
+		card.NewCard(card.Hearts, card.Four),
+		card.NewCard(card.Clubs, card.Seven),
+		card.NewCard(card.Diamonds, card.King),
+		card.NewCard(card.Hearts, card.Queen),
+	}
+	hole := []*card.Card{
+		card.NewCard(card.Spades, card.Ace),
+		card.NewCard(card.Clubs, card.Three),
+		card.NewCard(card.Diamonds, card.Five),
+		card.NewCard(card.Hearts, card.Six),
+	}
+
+	low := OmahaBestLow(hole, board)
+	if low == nil || low.Strength[0] != 7 || low.Strength[1] != 4 || low.Strength[2] != 3 {
+		t.Errorf("Expected a seven-four-three low using ace-three, got %v", low)
+	}
+
+	// Only two low board cards means no low, whatever the hand holds
+	board[2] = card.NewCard(card.Clubs, card.Jack)
+	if low := OmahaBestLow(hole, board); low != nil {
+		t.Errorf("Expected no low with two low board cards, got %v", low)
+	}
+}
+
+func TestAceFiveLow(t *testing.T) {
+	wheel := NewAceFiveLow([]*card.Card{
+		card.NewCard(card.Spades, card.Five),
+		card.NewCard(card.Spades, card.Four),
+		card.NewCard(card.Spades, card.Three),
+		card.NewCard(card.Spades, card.Two),
+		card.NewCard(card.Spades, card.Ace),
+	})
+	kingHigh := NewAceFiveLow([]*card.Card{
+		card.NewCard(card.Hearts, card.King),
+		card.NewCard(card.Clubs, card.Queen),
+		card.NewCard(card.Spades, card.Jack),
+		card.NewCard(card.Spades, card.Ten),
+		card.NewCard(card.Spades, card.Nine),
+	})
+	pairOfAces := NewAceFiveLow([]*card.Card{
+		card.NewCard(card.Hearts, card.Ace),
+		card.NewCard(card.Clubs, card.Ace),
+		card.NewCard(card.Spades, card.Four),
+		card.NewCard(card.Spades, card.Three),
+		card.NewCard(card.Spades, card.Two),
+	})
+	twoPair := NewAceFiveLow([]*card.Card{
+		card.NewCard(card.Hearts, card.Two),
+		card.NewCard(card.Clubs, card.Two),
+		card.NewCard(card.Spades, card.Ace),
+		card.NewCard(card.Hearts, card.Ace),
+		card.NewCard(card.Spades, card.Three),
+	})
+
+	if wheel.Rank != HighCard || kingHigh.Rank != HighCard || pairOfAces.Rank != OnePair || twoPair.Rank != TwoPair {
+		t.Fatalf("Expected straights and flushes to be ignored, got %v and %v", wheel.Rank, kingHigh.Rank)
+	}
+	if wheel.Compare(kingHigh) != 1 || kingHigh.Compare(pairOfAces) != 1 || pairOfAces.Compare(twoPair) != 1 {
+		t.Error("Expected the wheel to beat king high, which beats a pair, which beats two pair")
+	}
+
+	best := BestAceFiveLow([]*card.Card{
+		card.NewCard(card.Hearts, card.King),
+		card.NewCard(card.Clubs, card.Seven),
+		card.NewCard(card.Spades, card.Seven),
+		card.NewCard(card.Spades, card.Six),
+		card.NewCard(card.Hearts, card.Three),
+		card.NewCard(card.Diamonds, card.Two),
+		card.NewCard(card.Diamonds, card.Ace),
+	})
+	if best.Rank != HighCard || best.Strength[0] != 7 || best.Strength[1] != 6 {
+		t.Errorf("Expected a seven-six low from seven cards, got %v", best)
+	}
+}
@@ -0,0 +1,48 @@
+package hand
+
+import (
+	"github.com/prfc0/aksha/internal/card"
+)
+
+// NewDeuceSevenHand evaluates five cards for deuce-to-seven lowball. Aces are
+// always high, so A-2-3-4-5 is not a straight, and hands are ranked as in
+// high poker with the weakest winning: straights and flushes count against
+// a hand, and the best possible hand is 7-5-4-3-2 in mixed suits.
+func NewDeuceSevenHand(cards []*card.Card) *Hand {
+	hand := &Hand{
+		Cards:    cards,
+		Rank:     HighCard,
+		Strength: make([]int, 0),
+		acesHigh: true,
+	}
+	hand.evaluate()
+	return hand
+}
+
+// BestDeuceSevenHand returns the best deuce-to-seven hand that can be made
+// from any five of cards. Fewer than five cards are evaluated as they are.
+func BestDeuceSevenHand(cards []*card.Card) *Hand {
+	if len(cards) <= 5 {
+		return NewDeuceSevenHand(append([]*card.Card{}, cards...))
+	}
+
+	var best *Hand
+	combinations(len(cards), 5, func(combination []int) {
+		selected := make([]*card.Card, 5)
+		for i, index := range combination {
+			selected[i] = cards[index]
+		}
+		if candidate := NewDeuceSevenHand(selected); best == nil || candidate.CompareDeuceSeven(best) > 0 {
+			best = candidate
+		}
+	})
+	return best
+}
+
+// CompareDeuceSeven compares two deuce-to-seven hands and returns:
+// -1 if h is worse (stronger as a high hand) than other,
+// 0 if h is equal to other,
+// 1 if h is better (weaker as a high hand) than other.
+func (h *Hand) CompareDeuceSeven(other *Hand) int {
+	return -h.Compare(other)
+}
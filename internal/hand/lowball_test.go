@@ -0,0 +1,63 @@
+package hand
+
+import (
+	"testing"
+
+	"github.com/prfc0/aksha/internal/card"
+)
+
+func TestDeuceSevenHand(t *testing.T) {
+	number1 := NewDeuceSevenHand([]*card.Card{
+		card.NewCard(card.Spades, card.Seven),
+		card.NewCard(card.Hearts, card.Five),
+		card.NewCard(card.Spades, card.Four),
+		card.NewCard(card.Clubs, card.Three),
+		card.NewCard(card.Spades, card.Two),
+	})
+	aceHigh := NewDeuceSevenHand([]*card.Card{
+		card.NewCard(card.Spades, card.Ace),
+		card.NewCard(card.Hearts, card.Five),
+		card.NewCard(card.Spades, card.Four),
+		card.NewCard(card.Clubs, card.Three),
+		card.NewCard(card.Spades, card.Two),
+	})
+	straight := NewDeuceSevenHand([]*card.Card{
+		card.NewCard(card.Spades, card.Six),
+		card.NewCard(card.Hearts, card.Five),
+		card.NewCard(card.Spades, card.Four),
+		card.NewCard(card.Clubs, card.Three),
+		card.NewCard(card.Spades, card.Two),
+	})
+	flush := NewDeuceSevenHand([]*card.Card{
+		card.NewCard(card.Spades, card.Eight),
+		card.NewCard(card.Spades, card.Five),
+		card.NewCard(card.Spades, card.Four),
+		card.NewCard(card.Spades, card.Three),
+		card.NewCard(card.Spades, card.Two),
+	})
+	pair := NewDeuceSevenHand([]*card.Card{
+		card.NewCard(card.Spades, card.Two),
+		card.NewCard(card.Hearts, card.Five),
+		card.NewCard(card.Spades, card.Four),
+		card.NewCard(card.Clubs, card.Three),
+		card.NewCard(card.Hearts, card.Two),
+	})
+
+	if aceHigh.Rank != HighCard {
+		t.Errorf("Expected A-2-3-4-5 to be ace high, got %v", aceHigh.Rank)
+	}
+	tests := []struct {
+		name          string
+		better, worse *Hand
+	}{
+		{"seven low beats ace high", number1, aceHigh},
+		{"ace high beats a pair", aceHigh, pair},
+		{"a pair beats a straight", pair, straight},
+		{"a pair beats a flush", pair, flush},
+	}
+	for _, test := range tests {
+		if test.better.CompareDeuceSeven(test.worse) != 1 || test.worse.CompareDeuceSeven(test.better) != -1 {
+			t.Errorf("Expected %s", test.name)
+		}
+	}
+}
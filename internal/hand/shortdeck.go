@@ -0,0 +1,54 @@
+package hand
+
+import (
+	"github.com/prfc0/aksha/internal/card"
+)
+
+// ShortDeck ranks hands for short-deck (6+) Hold'em, played without the
+// twos through fives. A flush beats a full house, being the harder hand to
+// make, and A-6-7-8-9 is the lowest straight.
+type ShortDeck struct {
+	TripsBeatStraight bool // Whether three of a kind ranks above a straight, as some rooms play
+}
+
+// NewHand evaluates five cards by short-deck rules.
+func (s ShortDeck) NewHand(cards []*card.Card) *Hand {
+	hand := &Hand{
+		Cards:     cards,
+		Rank:      HighCard,
+		Strength:  make([]int, 0),
+		shortDeck: &s,
+	}
+	hand.evaluate()
+	return hand
+}
+
+// BestHand returns the strongest five-card short-deck hand that can be made
+// from cards. Fewer than five cards are evaluated as they are.
+func (s ShortDeck) BestHand(cards []*card.Card) *Hand {
+	if len(cards) <= 5 {
+		return s.NewHand(append([]*card.Card{}, cards...))
+	}
+
+	var best *Hand
+	combinations(len(cards), 5, func(combination []int) {
+		selected := make([]*card.Card, 5)
+		for i, index := range combination {
+			selected[i] = cards[index]
+		}
+		if candidate := s.NewHand(selected); best == nil || candidate.Compare(best) > 0 {
+			best = candidate
+		}
+	})
+	return best
+}
+
+// Evaluate returns the best short-deck hand from the hole cards and board.
+func (s ShortDeck) Evaluate(hole, board []*card.Card) Ranked {
+	return s.BestHand(join(hole, board))
+}
+
+// Compare compares two short-deck hands.
+func (s ShortDeck) Compare(a, b Ranked) int {
+	return a.(*Hand).Compare(b.(*Hand))
+}
@@ -0,0 +1,64 @@
+package hand
+
+import (
+	"testing"
+
+	"github.com/prfc0/aksha/internal/card"
+)
+
+func TestShortDeck(t *testing.T) {
+	flush := []*card.Card{
+		card.NewCard(card.Hearts, card.Ace),
+		card.NewCard(card.Hearts, card.Jack),
+		card.NewCard(card.Hearts, card.Nine),
+		card.NewCard(card.Hearts, card.Eight),
+		card.NewCard(card.Hearts, card.Six),
+	}
+	fullHouse := []*card.Card{
+		card.NewCard(card.Spades, card.King),
+		card.NewCard(card.Hearts, card.King),
+		card.NewCard(card.Clubs, card.King),
+		card.NewCard(card.Spades, card.Queen),
+		card.NewCard(card.Hearts, card.Queen),
+	}
+	wheel := []*card.Card{
+		card.NewCard(card.Spades, card.Ace),
+		card.NewCard(card.Hearts, card.Nine),
+		card.NewCard(card.Clubs, card.Eight),
+		card.NewCard(card.Spades, card.Seven),
+		card.NewCard(card.Diamonds, card.Six),
+	}
+	sixHigh := []*card.Card{
+		card.NewCard(card.Spades, card.Ten),
+		card.NewCard(card.Hearts, card.Nine),
+		card.NewCard(card.Clubs, card.Eight),
+		card.NewCard(card.Spades, card.Seven),
+		card.NewCard(card.Diamonds, card.Six),
+	}
+	trips := []*card.Card{
+		card.NewCard(card.Spades, card.Six),
+		card.NewCard(card.Hearts, card.Six),
+		card.NewCard(card.Clubs, card.Six),
+		card.NewCard(card.Spades, card.Ace),
+		card.NewCard(card.Diamonds, card.King),
+	}
+
+	rules := ShortDeck{}
+	if rules.NewHand(wheel).Rank != Straight || NewHand(append([]*card.Card{}, wheel...)).Rank != HighCard {
+		t.Error("Expected A-6-7-8-9 to be a straight only in short-deck")
+	}
+	if rules.NewHand(flush).Compare(rules.NewHand(fullHouse)) != 1 {
+		t.Error("Expected a flush to beat a full house")
+	}
+	if rules.NewHand(sixHigh).Compare(rules.NewHand(wheel)) != 1 {
+		t.Error("Expected A-6-7-8-9 to be the lowest straight")
+	}
+	if rules.NewHand(wheel).Compare(rules.NewHand(trips)) != 1 {
+		t.Error("Expected a straight to beat three of a kind by default")
+	}
+
+	rules.TripsBeatStraight = true
+	if rules.NewHand(trips).Compare(rules.NewHand(wheel)) != 1 {
+		t.Error("Expected three of a kind to beat a straight when configured")
+	}
+}
@@ -0,0 +1,125 @@
+package icm
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Method is how a deal splits the prize pool.
+type Method int
+
+const (
+	ICM      Method = iota // By each player's Malmuth-Harville equity
+	ChipChop               // Each player gets the smallest prize left plus a share of the rest in proportion to their stack
+)
+
+func (m Method) String() string {
+	switch m {
+	case ICM:
+		return "ICM"
+	case ChipChop:
+		return "chip chop"
+	default:
+		return "unknown"
+	}
+}
+
+// Deal is a proposed split of the prizes still to be won.
+type Deal struct {
+	Method  Method
+	Amounts []int // Amount each player locks up, in the order the stacks were given
+	PlayFor int   // Amount left for the winner of the remaining play
+}
+
+// ProposeDeal splits the prizes for the places still to be decided between
+// the players, keeping playFor back from first prize for them to play on
+// for. payouts lists every prize from first place down; only the top places
+// for the number of players still in are shared.
+func ProposeDeal(method Method, stacks, payouts []int, playFor int) (*Deal, error) {
+	if err := validate(stacks, payouts); err != nil {
+		return nil, err
+	}
+	if len(payouts) == 0 {
+		return nil, fmt.Errorf("no payouts given")
+	}
+
+	remaining := append([]int{}, payouts[:paidPlaces(stacks, payouts)]...)
+	next := 0
+	if len(remaining) > 1 {
+		next = remaining[1]
+	}
+	if playFor < 0 || playFor > remaining[0]-next {
+		return nil, fmt.Errorf("amount to play for must be between 0 and %d, got %d", remaining[0]-next, playFor)
+	}
+	remaining[0] -= playFor
+
+	pool := 0
+	for _, payout := range remaining {
+		pool += payout
+	}
+
+	var shares []float64
+	switch method {
+	case ICM:
+		var err error
+		if shares, err = Equity(stacks, remaining); err != nil {
+			return nil, err
+		}
+	case ChipChop:
+		shares = chipChop(stacks, remaining, pool)
+	default:
+		return nil, fmt.Errorf("unknown deal method %d", method)
+	}
+
+	return &Deal{Method: method, Amounts: roundShares(shares, stacks, pool), PlayFor: playFor}, nil
+}
+
+// chipChop guarantees each player the smallest prize still paid, or nothing
+// if some players will go unpaid, and splits the rest by stack.
+func chipChop(stacks, payouts []int, pool int) []float64 {
+	minimum := 0
+	if len(payouts) == len(stacks) {
+		minimum = payouts[len(payouts)-1]
+	}
+	total := 0
+	for _, stack := range stacks {
+		total += stack
+	}
+	rest := pool - minimum*len(stacks)
+
+	shares := make([]float64, len(stacks))
+	for i, stack := range stacks {
+		shares[i] = float64(minimum) + float64(rest)*float64(stack)/float64(total)
+	}
+	return shares
+}
+
+// roundShares rounds each share down to whole chips and hands out what that
+// leaves, one chip at a time, to the largest fractions first and then the
+// biggest stacks.
+func roundShares(shares []float64, stacks []int, pool int) []int {
+	amounts := make([]int, len(shares))
+	left := pool
+	for i, share := range shares {
+		amounts[i] = int(share)
+		left -= amounts[i]
+	}
+
+	order := make([]int, len(shares))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		fractionA := shares[order[a]] - float64(amounts[order[a]])
+		fractionB := shares[order[b]] - float64(amounts[order[b]])
+		if fractionA != fractionB {
+			return fractionA > fractionB
+		}
+		return stacks[order[a]] > stacks[order[b]]
+	})
+	for i := 0; left > 0 && len(order) > 0; i = (i + 1) % len(order) {
+		amounts[order[i]]++
+		left--
+	}
+	return amounts
+}
@@ -0,0 +1,146 @@
+package icm
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// exactLimit is the most finishing positions the exact calculation will
+// enumerate before Equity switches to sampling.
+const exactLimit = 2000000
+
+// DefaultTrials is the number of finishing orders Equity samples for fields
+// too large to calculate exactly.
+const DefaultTrials = 200000
+
+// Equity returns each player's expected prize under the Malmuth-Harville
+// model, in which a player finishes first with probability proportional to
+// their stack, and each later place is decided the same way among the
+// players left. payouts lists the prize for each place, first place first.
+// Large fields are approximated by sampling.
+func Equity(stacks, payouts []int) ([]float64, error) {
+	if err := validate(stacks, payouts); err != nil {
+		return nil, err
+	}
+	if exactPositions(len(stacks), paidPlaces(stacks, payouts)) > exactLimit {
+		return ApproximateEquity(stacks, payouts, DefaultTrials, rand.New(rand.NewSource(1)))
+	}
+	return ExactEquity(stacks, payouts)
+}
+
+// ExactEquity calculates Malmuth-Harville equity exactly by working through
+// every set of players who could take the paid places above the rest.
+func ExactEquity(stacks, payouts []int) ([]float64, error) {
+	if err := validate(stacks, payouts); err != nil {
+		return nil, err
+	}
+	if len(stacks) > 64 {
+		return nil, fmt.Errorf("exact equity supports at most 64 players, got %d", len(stacks))
+	}
+
+	total := 0
+	for _, stack := range stacks {
+		total += stack
+	}
+	equity := make([]float64, len(stacks))
+
+	// finished maps each set of players who took the places so far to the
+	// probability that exactly they did
+	finished := map[uint64]float64{0: 1}
+	for place := 0; place < paidPlaces(stacks, payouts); place++ {
+		next := make(map[uint64]float64)
+		for taken, probability := range finished {
+			remaining := total
+			for i, stack := range stacks {
+				if taken&(1<<i) != 0 {
+					remaining -= stack
+				}
+			}
+			for i, stack := range stacks {
+				if taken&(1<<i) != 0 {
+					continue
+				}
+				p := probability * float64(stack) / float64(remaining)
+				equity[i] += p * float64(payouts[place])
+				next[taken|1<<i] += p
+			}
+		}
+		finished = next
+	}
+	return equity, nil
+}
+
+// ApproximateEquity estimates Malmuth-Harville equity by sampling finishing
+// orders. Ordering players by an exponential draw divided by their stack
+// picks each order with exactly the model's probability.
+func ApproximateEquity(stacks, payouts []int, trials int, r *rand.Rand) ([]float64, error) {
+	if err := validate(stacks, payouts); err != nil {
+		return nil, err
+	}
+	if trials <= 0 {
+		return nil, fmt.Errorf("trials must be positive, got %d", trials)
+	}
+
+	places := paidPlaces(stacks, payouts)
+	equity := make([]float64, len(stacks))
+	order := make([]int, len(stacks))
+	keys := make([]float64, len(stacks))
+	for trial := 0; trial < trials; trial++ {
+		for i, stack := range stacks {
+			order[i] = i
+			keys[i] = r.ExpFloat64() / float64(stack)
+		}
+		sort.Slice(order, func(a, b int) bool {
+			return keys[order[a]] < keys[order[b]]
+		})
+		for place := 0; place < places; place++ {
+			equity[order[place]] += float64(payouts[place])
+		}
+	}
+	for i := range equity {
+		equity[i] /= float64(trials)
+	}
+	return equity, nil
+}
+
+func validate(stacks, payouts []int) error {
+	if len(stacks) == 0 {
+		return fmt.Errorf("no stacks given")
+	}
+	for i, stack := range stacks {
+		if stack <= 0 {
+			return fmt.Errorf("stack %d must be positive, got %d", i, stack)
+		}
+	}
+	for i, payout := range payouts {
+		if payout < 0 {
+			return fmt.Errorf("payout for place %d must not be negative, got %d", i+1, payout)
+		}
+	}
+	return nil
+}
+
+// paidPlaces returns how many places pay among the players still in.
+func paidPlaces(stacks, payouts []int) int {
+	if len(payouts) < len(stacks) {
+		return len(payouts)
+	}
+	return len(stacks)
+}
+
+// exactPositions estimates the work of the exact calculation: the number of
+// sets of players that can take the first places, times the players each
+// set leaves.
+func exactPositions(players, places int) float64 {
+	positions, sets := 0.0, 1.0
+	for place := 0; place < places; place++ {
+		positions += sets * float64(players-place)
+		sets = sets * float64(players-place) / float64(place+1)
+		if math.IsInf(sets, 0) {
+			return math.Inf(1)
+		}
+	}
+	return positions
+}
@@ -0,0 +1,92 @@
+package icm
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestExactEquity(t *testing.T) {
+	tests := []struct {
+		stacks   []int
+		payouts  []int
+		expected []float64
+	}{
+		{[]int{5000, 3000, 2000}, []int{50, 30, 20}, []float64{38.3929, 32.75, 28.8571}},
+		{[]int{1000, 1000}, []int{70, 30}, []float64{50, 50}},
+		{[]int{9000, 1000}, []int{100}, []float64{90, 10}},
+		// Players outside the paid places get nothing for finishing there
+		{[]int{4000, 4000, 2000}, []int{100}, []float64{40, 40, 20}},
+	}
+
+	for _, test := range tests {
+		equity, err := ExactEquity(test.stacks, test.payouts)
+		if err != nil {
+			t.Errorf("Unexpected error for %v: %v", test.stacks, err)
+			continue
+		}
+		for i := range test.expected {
+			if math.Abs(equity[i]-test.expected[i]) > 0.001 {
+				t.Errorf("Expected equity %v for stacks %v, got %v", test.expected, test.stacks, equity)
+				break
+			}
+		}
+	}
+
+	if _, err := ExactEquity([]int{1000, 0}, []int{100}); err == nil {
+		t.Errorf("Expected error for an empty stack")
+	}
+}
+
+func TestApproximateEquity(t *testing.T) {
+	stacks := []int{12000, 8000, 6000, 5000, 4000, 3000, 1500, 500}
+	payouts := []int{400, 250, 150, 100, 60, 40}
+
+	exact, _ := ExactEquity(stacks, payouts)
+	approximate, err := ApproximateEquity(stacks, payouts, 200000, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for i := range exact {
+		if math.Abs(exact[i]-approximate[i]) > 1 {
+			t.Errorf("Expected approximate equity close to %.2f for stack %d, got %.2f", exact[i], stacks[i], approximate[i])
+		}
+	}
+}
+
+func TestProposeDeal(t *testing.T) {
+	stacks := []int{5000, 3000, 2000}
+	payouts := []int{500, 300, 200, 100}
+
+	tests := []struct {
+		method   Method
+		playFor  int
+		expected []int
+	}{
+		{ICM, 100, []int{334, 297, 269}},
+		{ChipChop, 100, []int{350, 290, 260}},
+		{ChipChop, 0, []int{400, 320, 280}},
+	}
+	for _, test := range tests {
+		deal, err := ProposeDeal(test.method, stacks, payouts, test.playFor)
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %v", test.method, err)
+			continue
+		}
+		total := deal.PlayFor
+		for i, amount := range deal.Amounts {
+			total += amount
+			if amount != test.expected[i] {
+				t.Errorf("Expected %s deal %v leaving %d, got %v", test.method, test.expected, test.playFor, deal.Amounts)
+				break
+			}
+		}
+		if total != 1000 {
+			t.Errorf("Expected the %s deal to share out 1000, got %d", test.method, total)
+		}
+	}
+
+	if _, err := ProposeDeal(ICM, stacks, payouts, 250); err == nil {
+		t.Errorf("Expected error leaving more to play for than first prize is worth over second")
+	}
+}
@@ -0,0 +1,269 @@
+package lobby
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/prfc0/aksha/internal/player"
+	"github.com/prfc0/aksha/internal/table"
+)
+
+// Stakes identifies tables playing the same blinds, so players can wait for
+// whichever of them opens up first.
+type Stakes struct {
+	SmallBlind int `json:"smallBlind"`
+	BigBlind   int `json:"bigBlind"`
+}
+
+// Offer is a seat held for a player from the waiting list until it expires.
+type Offer struct {
+	PlayerID string    `json:"playerId"`
+	TableID  string    `json:"tableId"`
+	Seat     int       `json:"seat"`
+	Expires  time.Time `json:"expires"`
+}
+
+// QueuePosition is a player's place on one waiting list, counting from 1.
+type QueuePosition struct {
+	TableID  string `json:"tableId"` // Empty when waiting for any table at the stakes
+	Stakes   Stakes `json:"stakes"`
+	Position int    `json:"position"`
+}
+
+// Status is what a waiting player is told about their place in the lobby.
+type Status struct {
+	Positions []QueuePosition `json:"positions"`
+	Offer     *Offer          `json:"offer"`
+}
+
+// Lobby keeps the waiting lists for a set of tables, one per table and one
+// per stakes, and offers open seats to the players who have waited longest.
+type Lobby struct {
+	OfferTimeout time.Duration // How long a player has to accept an offered seat
+
+	tables  map[string]*table.Table
+	stakes  map[string]Stakes
+	waiting []*entry          // Waiting players, in the order they joined
+	offers  map[string]*Offer // Outstanding offers, by player ID
+	clock   func() time.Time  // Current time, replaced in tests
+}
+
+// entry is a player waiting for a particular table, or for any table at the
+// given stakes when tableID is empty.
+type entry struct {
+	player  *player.Player
+	tableID string
+	stakes  Stakes
+}
+
+func NewLobby(offerTimeout time.Duration) *Lobby {
+	return &Lobby{
+		OfferTimeout: offerTimeout,
+		tables:       make(map[string]*table.Table),
+		stakes:       make(map[string]Stakes),
+		waiting:      make([]*entry, 0),
+		offers:       make(map[string]*Offer),
+		clock:        time.Now,
+	}
+}
+
+// AddTable makes a table available in the lobby and offers its open seats
+// to waiting players whenever one frees up.
+func (l *Lobby) AddTable(tableID string, t *table.Table, stakes Stakes) {
+	l.tables[tableID] = t
+	l.stakes[tableID] = stakes
+	t.SeatOpened = func(seat int) {
+		l.fill(tableID)
+	}
+	log.Printf("Table %s opened at %d/%d.\n", tableID, stakes.SmallBlind, stakes.BigBlind)
+}
+
+// JoinTable puts a player on the waiting list for one table.
+func (l *Lobby) JoinTable(p *player.Player, tableID string) error {
+	if _, ok := l.tables[tableID]; !ok {
+		return fmt.Errorf("table %s does not exist", tableID)
+	}
+	return l.join(&entry{player: p, tableID: tableID, stakes: l.stakes[tableID]})
+}
+
+// JoinStakes puts a player on the waiting list for the first seat at any
+// table playing the given stakes.
+func (l *Lobby) JoinStakes(p *player.Player, stakes Stakes) error {
+	for _, tableStakes := range l.stakes {
+		if tableStakes == stakes {
+			return l.join(&entry{player: p, stakes: stakes})
+		}
+	}
+	return fmt.Errorf("no tables play %d/%d", stakes.SmallBlind, stakes.BigBlind)
+}
+
+func (l *Lobby) join(e *entry) error {
+	for _, waiting := range l.waiting {
+		if waiting.player.ID == e.player.ID && waiting.tableID == e.tableID && waiting.stakes == e.stakes {
+			return fmt.Errorf("player %s is already waiting", e.player.Name)
+		}
+	}
+	l.waiting = append(l.waiting, e)
+	log.Printf("Player %s joined the waiting list.\n", e.player.Name)
+	l.fillAll()
+	return nil
+}
+
+// Leave takes a player off every waiting list and gives up any offer.
+func (l *Lobby) Leave(playerID string) {
+	l.removeWaiting(playerID)
+	l.Decline(playerID)
+}
+
+// Status returns a player's position on each waiting list they are on and
+// any seat currently offered to them.
+func (l *Lobby) Status(playerID string) Status {
+	status := Status{Positions: make([]QueuePosition, 0), Offer: l.offers[playerID]}
+	for i, e := range l.waiting {
+		if e.player.ID != playerID {
+			continue
+		}
+		position := 1
+		for _, ahead := range l.waiting[:i] {
+			if ahead.tableID == e.tableID && ahead.stakes == e.stakes {
+				position++
+			}
+		}
+		status.Positions = append(status.Positions, QueuePosition{TableID: e.tableID, Stakes: e.stakes, Position: position})
+	}
+	return status
+}
+
+// Accept seats a player in the seat offered to them with the given buy-in.
+func (l *Lobby) Accept(playerID string, buyIn int) error {
+	offer, ok := l.offers[playerID]
+	if !ok || !l.clock().Before(offer.Expires) {
+		return fmt.Errorf("player %s has no seat on offer", playerID)
+	}
+	var p *player.Player
+	for _, e := range l.waiting {
+		if e.player.ID == playerID {
+			p = e.player
+		}
+	}
+	if p == nil {
+		return fmt.Errorf("player %s is not waiting", playerID)
+	}
+
+	if err := l.tables[offer.TableID].SitDown(offer.Seat, p, buyIn); err != nil {
+		return err
+	}
+	delete(l.offers, playerID)
+	l.removeWaiting(playerID)
+	return nil
+}
+
+// Decline gives up the seat offered to a player, who leaves the waiting list
+// it came from, and offers it to the next player.
+func (l *Lobby) Decline(playerID string) {
+	offer, ok := l.offers[playerID]
+	if !ok {
+		return
+	}
+	l.withdraw(offer)
+	l.fill(offer.TableID)
+}
+
+// Update withdraws expired offers and offers any open seats to the players
+// waiting for them. It should be called periodically.
+func (l *Lobby) Update() {
+	for _, offer := range l.offers {
+		if !l.clock().Before(offer.Expires) {
+			log.Printf("Offer of seat %d at table %s to player %s expired.\n", offer.Seat, offer.TableID, offer.PlayerID)
+			l.withdraw(offer)
+		}
+	}
+	l.fillAll()
+}
+
+// withdraw cancels an offer, frees its seat and drops the player from the
+// waiting lists that covered that table.
+func (l *Lobby) withdraw(offer *Offer) {
+	delete(l.offers, offer.PlayerID)
+	l.tables[offer.TableID].Release(offer.Seat)
+	remaining := make([]*entry, 0, len(l.waiting))
+	for _, e := range l.waiting {
+		if e.player.ID != offer.PlayerID || !l.covers(e, offer.TableID) {
+			remaining = append(remaining, e)
+		}
+	}
+	l.waiting = remaining
+}
+
+// fillAll fills open seats at every table, in table ID order.
+func (l *Lobby) fillAll() {
+	tableIDs := make([]string, 0, len(l.tables))
+	for tableID := range l.tables {
+		tableIDs = append(tableIDs, tableID)
+	}
+	sort.Strings(tableIDs)
+	for _, tableID := range tableIDs {
+		l.fill(tableID)
+	}
+}
+
+// fill offers each open seat at a table to the longest waiting player who
+// wants that table and has no other offer outstanding.
+func (l *Lobby) fill(tableID string) {
+	t := l.tables[tableID]
+	for _, seat := range t.EmptySeats() {
+		next := l.nextWaiting(tableID)
+		if next == nil {
+			return
+		}
+		if err := t.Reserve(seat, next.player.ID); err != nil {
+			log.Printf("Could not hold seat %d at table %s: %v\n", seat, tableID, err)
+			return
+		}
+		l.offers[next.player.ID] = &Offer{
+			PlayerID: next.player.ID,
+			TableID:  tableID,
+			Seat:     seat,
+			Expires:  l.clock().Add(l.OfferTimeout),
+		}
+		log.Printf("Offered seat %d at table %s to player %s.\n", seat, tableID, next.player.Name)
+	}
+}
+
+func (l *Lobby) nextWaiting(tableID string) *entry {
+	for _, e := range l.waiting {
+		if _, offered := l.offers[e.player.ID]; !offered && l.covers(e, tableID) && !l.seated(e.player.ID, tableID) {
+			return e
+		}
+	}
+	return nil
+}
+
+// covers reports whether a waiting list entry would take a seat at the table.
+func (l *Lobby) covers(e *entry, tableID string) bool {
+	if e.tableID != "" {
+		return e.tableID == tableID
+	}
+	return e.stakes == l.stakes[tableID]
+}
+
+func (l *Lobby) seated(playerID, tableID string) bool {
+	for _, p := range l.tables[tableID].Players {
+		if p.ID == playerID {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *Lobby) removeWaiting(playerID string) {
+	remaining := make([]*entry, 0, len(l.waiting))
+	for _, e := range l.waiting {
+		if e.player.ID != playerID {
+			remaining = append(remaining, e)
+		}
+	}
+	l.waiting = remaining
+}
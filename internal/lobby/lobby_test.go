@@ -0,0 +1,152 @@
+package lobby
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prfc0/aksha/internal/player"
+	"github.com/prfc0/aksha/internal/table"
+)
+
+var lowStakes = Stakes{SmallBlind: 1, BigBlind: 2}
+
+func newTestLobby() (*Lobby, *time.Time) {
+	now := time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)
+	lobby := NewLobby(time.Minute)
+	lobby.clock = func() time.Time { return now }
+	return lobby, &now
+}
+
+func newFullTable() *table.Table {
+	t := table.NewTable(2)
+	t.AddPlayer(player.NewPlayer("1", "Alice", 100))
+	t.AddPlayer(player.NewPlayer("2", "Bob", 100))
+	return t
+}
+
+func TestQueuePositions(t *testing.T) {
+	lobby, _ := newTestLobby()
+	lobby.AddTable("a", newFullTable(), lowStakes)
+	lobby.AddTable("b", newFullTable(), lowStakes)
+
+	charlie := player.NewPlayer("3", "Charlie", 0)
+	dave := player.NewPlayer("4", "Dave", 0)
+	lobby.JoinTable(charlie, "a")
+	lobby.JoinTable(dave, "a")
+	lobby.JoinStakes(dave, lowStakes)
+
+	status := lobby.Status("4")
+	if len(status.Positions) != 2 {
+		t.Fatalf("Expected Dave on two waiting lists, got %v", status.Positions)
+	}
+	if status.Positions[0].TableID != "a" || status.Positions[0].Position != 2 {
+		t.Errorf("Expected Dave second for table a, got %+v", status.Positions[0])
+	}
+	if status.Positions[1].TableID != "" || status.Positions[1].Position != 1 {
+		t.Errorf("Expected Dave first for any table at the stakes, got %+v", status.Positions[1])
+	}
+
+	if err := lobby.JoinTable(dave, "a"); err == nil {
+		t.Error("Expected an error joining the same list twice")
+	}
+	if err := lobby.JoinTable(dave, "c"); err == nil {
+		t.Error("Expected an error joining the list of a table that does not exist")
+	}
+	if err := lobby.JoinStakes(dave, Stakes{SmallBlind: 5, BigBlind: 10}); err == nil {
+		t.Error("Expected an error joining stakes nobody plays")
+	}
+}
+
+func TestSeatOfferedWhenFreed(t *testing.T) {
+	lobby, _ := newTestLobby()
+	tableA := newFullTable()
+	lobby.AddTable("a", tableA, lowStakes)
+
+	charlie := player.NewPlayer("3", "Charlie", 0)
+	lobby.JoinTable(charlie, "a")
+	if lobby.Status("3").Offer != nil {
+		t.Fatal("Expected no offer while the table is full")
+	}
+
+	tableA.StandUp("1")
+	offer := lobby.Status("3").Offer
+	if offer == nil || offer.TableID != "a" || offer.Seat != 0 {
+		t.Fatalf("Expected Charlie to be offered seat 0 at table a, got %+v", offer)
+	}
+
+	// The seat is held for Charlie
+	if err := tableA.AddPlayer(player.NewPlayer("5", "Eve", 100)); err == nil {
+		t.Error("Expected the offered seat to be held")
+	}
+
+	if err := lobby.Accept("3", 100); err != nil {
+		t.Fatalf("Unexpected error accepting the seat: %v", err)
+	}
+	if tableA.PlayerAt(0) != charlie || len(lobby.Status("3").Positions) != 0 {
+		t.Error("Expected Charlie to be seated and off the waiting list")
+	}
+}
+
+func TestOfferAcrossStakes(t *testing.T) {
+	lobby, _ := newTestLobby()
+	tableA := newFullTable()
+	tableB := newFullTable()
+	lobby.AddTable("a", tableA, lowStakes)
+	lobby.AddTable("b", tableB, lowStakes)
+
+	charlie := player.NewPlayer("3", "Charlie", 0)
+	dave := player.NewPlayer("4", "Dave", 0)
+	lobby.JoinStakes(charlie, lowStakes)
+	lobby.JoinTable(dave, "b")
+
+	tableB.StandUp("2")
+	if offer := lobby.Status("3").Offer; offer == nil || offer.TableID != "b" {
+		t.Errorf("Expected the longest waiting player for the stakes to get the seat, got %+v", offer)
+	}
+	if lobby.Status("4").Offer != nil {
+		t.Error("Expected Dave to keep waiting")
+	}
+}
+
+func TestOfferExpires(t *testing.T) {
+	lobby, now := newTestLobby()
+	tableA := newFullTable()
+	lobby.AddTable("a", tableA, lowStakes)
+
+	lobby.JoinTable(player.NewPlayer("3", "Charlie", 0), "a")
+	lobby.JoinTable(player.NewPlayer("4", "Dave", 0), "a")
+	tableA.StandUp("1")
+
+	*now = now.Add(2 * time.Minute)
+	if err := lobby.Accept("3", 100); err == nil {
+		t.Error("Expected an expired offer not to be accepted")
+	}
+	lobby.Update()
+
+	if lobby.Status("3").Offer != nil || len(lobby.Status("3").Positions) != 0 {
+		t.Error("Expected Charlie to lose the offer and leave the list")
+	}
+	if offer := lobby.Status("4").Offer; offer == nil || offer.Seat != 0 {
+		t.Errorf("Expected the seat to be offered to Dave, got %+v", offer)
+	}
+}
+
+func TestDecline(t *testing.T) {
+	lobby, _ := newTestLobby()
+	tableA := newFullTable()
+	lobby.AddTable("a", tableA, lowStakes)
+
+	lobby.JoinTable(player.NewPlayer("3", "Charlie", 0), "a")
+	lobby.JoinTable(player.NewPlayer("4", "Dave", 0), "a")
+	tableA.StandUp("1")
+
+	lobby.Decline("3")
+	if lobby.Status("4").Offer == nil {
+		t.Error("Expected a declined seat to be offered to the next player")
+	}
+
+	lobby.Leave("4")
+	if seats := tableA.EmptySeats(); len(seats) != 1 {
+		t.Errorf("Expected the seat to be free once nobody is waiting, got %v", seats)
+	}
+}
@@ -8,14 +8,34 @@ import (
 	"github.com/prfc0/aksha/internal/card"
 )
 
+// Status describes where a player stands in the current hand.
+type Status string
+
+const (
+	StatusActive     Status = "Active"      // In the hand with chips left to act with
+	StatusAllIn      Status = "All-in"      // In the hand with every chip committed
+	StatusFolded     Status = "Folded"      // Folded this hand
+	StatusSittingOut Status = "Sitting out" // Not dealt into this hand
+)
+
 // Player represents a poker player.
 type Player struct {
-	ID     string       // Unique identifier for the player
-	Name   string       // Name of the player
-	Stack  int          // Chip stack
-	Hand   []*card.Card // Player's hand of cards
-	Bet    int          // Amount he has already bet
-	Active bool         // Whether the player is still in the current hand
+	ID                 string       // Unique identifier for the player
+	Name               string       // Name of the player
+	Stack              int          // Chip stack
+	Hand               []*card.Card // Player's hand of cards
+	Bet                int          // Chips committed on the current betting round, not counting antes
+	Committed          int          // Chips committed over the whole hand, including antes
+	Active             bool         // Whether the player is still in the current hand
+	Folded             bool         // Whether the player folded the current hand
+	AllIn              bool         // Whether the player has committed every chip to the current hand
+	Seat               int          // Seat number at the table
+	SittingOut         bool         // Whether the player is sitting out and not dealt in
+	SitOutNextHand     bool         // Whether the player sits out from the next hand
+	SitOutNextBigBlind bool         // Whether the player sits out when the big blind next reaches them
+	PendingChips       int          // Chips added during a hand, moved to the stack when it ends
+	MissedSmallBlind   bool         // Whether the player owes a dead small blind on returning
+	MissedBigBlind     bool         // Whether the player owes a big blind on returning
 }
 
 func NewPlayer(id, name string, stack int) *Player {
@@ -33,29 +53,99 @@ func (p *Player) AddCard(card *card.Card) {
 	log.Printf("Player %s received a card: %s\n", p.Name, card.String())
 }
 
-// PerformAction performs an action (e.g., Bet, Call, Raise, Fold).
+// PerformAction performs an action (e.g., Bet, Call, Raise, Fold). The
+// action amount is the number of chips the player puts in with it.
 func (p *Player) PerformAction(actionObj *action.Action, currentBet int) error {
 	newStack, _, err := actionObj.Execute(p.Stack, currentBet)
 	if err != nil {
 		return err
 	}
 
-	p.Bet += currentBet
-	p.Stack = newStack
+	if actionObj.Type == action.Fold {
+		p.Fold()
+		return nil
+	}
+	p.commit(p.Stack-newStack, true)
 	return nil
 }
 
+// Commit moves up to amount chips from the stack into the player's bet on
+// the current betting round and returns how many were committed.
+func (p *Player) Commit(amount int) int {
+	return p.commit(amount, true)
+}
+
+// CommitDead moves up to amount chips from the stack into the hand without
+// counting them towards the player's bet, as with antes and dead blinds, and
+// returns how many were committed.
+func (p *Player) CommitDead(amount int) int {
+	return p.commit(amount, false)
+}
+
+func (p *Player) commit(amount int, live bool) int {
+	if amount > p.Stack {
+		amount = p.Stack
+	}
+	p.Stack -= amount
+	p.Committed += amount
+	if live {
+		p.Bet += amount
+	}
+	if p.Stack == 0 && amount > 0 {
+		p.AllIn = true
+		log.Printf("Player %s is all-in.\n", p.Name)
+	}
+	return amount
+}
+
+// StartBettingRound clears the player's bet for a new betting round.
+func (p *Player) StartBettingRound() {
+	p.Bet = 0
+}
+
 func (p *Player) Fold() {
 	p.Active = false
+	p.Folded = true
 	log.Printf("Player %s folded.\n", p.Name)
 }
 
+// Status returns where the player stands in the current hand.
+func (p *Player) Status() Status {
+	switch {
+	case p.Folded:
+		return StatusFolded
+	case !p.Active:
+		return StatusSittingOut
+	case p.AllIn:
+		return StatusAllIn
+	default:
+		return StatusActive
+	}
+}
+
+// SitOut stops the player from being dealt into hands.
+func (p *Player) SitOut() {
+	p.SittingOut = true
+	log.Printf("Player %s is sitting out.\n", p.Name)
+}
+
+// SitIn deals the player into hands again. Any missed blinds are posted in
+// the next hand.
+func (p *Player) SitIn() {
+	p.SittingOut = false
+	log.Printf("Player %s is back in.\n", p.Name)
+}
+
 func (p *Player) ResetHand() {
 	p.Hand = make([]*card.Card, 0)
+	p.Bet = 0
+	p.Committed = 0
 	p.Active = true
+	p.Folded = false
+	p.AllIn = false
 	log.Printf("Player %s's hand and status reset for a new round.\n", p.Name)
 }
 
 func (p *Player) String() string {
-	return fmt.Sprintf("Player %s (Stack: %d, Active: %v)", p.Name, p.Stack, p.Active)
+	return fmt.Sprintf("Player %s (Stack: %d, Status: %s)", p.Name, p.Stack, p.Status())
 }
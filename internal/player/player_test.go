@@ -44,11 +44,35 @@ func TestPerformAction(t *testing.T) {
 	}
 }
 
+func TestCommit(t *testing.T) {
+	player := NewPlayer("1", "Alice", 100)
+
+	player.CommitDead(5)
+	player.PerformAction(action.NewAction(action.Bet, 20), 0)
+	player.PerformAction(action.NewAction(action.Raise, 40), 60)
+	if player.Bet != 60 || player.Committed != 65 || player.Stack != 35 {
+		t.Errorf("Expected bet 60, committed 65, stack 35, got bet %d, committed %d, stack %d", player.Bet, player.Committed, player.Stack)
+	}
+
+	player.StartBettingRound()
+	if committed := player.Commit(50); committed != 35 {
+		t.Errorf("Expected a short stack to commit 35, got %d", committed)
+	}
+	if player.Bet != 35 || player.Committed != 100 || !player.AllIn || player.Status() != StatusAllIn {
+		t.Errorf("Expected an all-in bet of 35 with 100 committed, got bet %d, committed %d, status %s", player.Bet, player.Committed, player.Status())
+	}
+
+	player.ResetHand()
+	if player.Bet != 0 || player.Committed != 0 || player.AllIn {
+		t.Error("Expected ResetHand to clear the chips committed")
+	}
+}
+
 func TestFold(t *testing.T) {
 	player := NewPlayer("1", "Alice", 1000)
 	player.Fold()
 
-	if player.Active {
+	if player.Active || !player.Folded || player.Status() != StatusFolded {
 		t.Error("Expected player to be inactive after folding")
 	}
 }
@@ -63,3 +87,15 @@ func TestResetHand(t *testing.T) {
 		t.Error("ResetHand did not reset the player correctly")
 	}
 }
+
+func TestSitOut(t *testing.T) {
+	player := NewPlayer("1", "Alice", 1000)
+	player.SitOut()
+	if !player.SittingOut {
+		t.Error("Expected player to be sitting out")
+	}
+	player.SitIn()
+	if player.SittingOut {
+		t.Error("Expected player to be back in")
+	}
+}
@@ -2,6 +2,7 @@ package pot
 
 import (
 	"log"
+	"sort"
 
 	"github.com/prfc0/aksha/internal/player"
 )
@@ -35,14 +36,112 @@ func (p *Pot) Distribute(winners []*player.Player) {
 		return
 	}
 
-	// Split the pot equally among winners
-	chipsPerWinner := p.Chips / len(winners)
-	for _, winner := range winners {
-		winner.Stack += chipsPerWinner
-		log.Printf("Player %s wins %d chips from the pot.\n", winner.Name, chipsPerWinner)
+	award(winners, p.Chips)
+
+	// Reset the pot
+	p.Chips = 0
+	p.Eligible = make([]*player.Player, 0)
+}
+
+// DistributeSplit divides the pot in half between the best high hands and
+// the best qualifying low hands, with the odd chip going to the high half.
+// A player who wins or ties both halves is paid from each, so a tied low
+// earns a quarter of the pot. With no qualifying low the high hands take
+// the whole pot.
+func (p *Pot) DistributeSplit(highWinners, lowWinners []*player.Player) {
+	if len(lowWinners) == 0 {
+		p.Distribute(highWinners)
+		return
 	}
 
+	lowHalf := p.Chips / 2
+	award(highWinners, p.Chips-lowHalf)
+	award(lowWinners, lowHalf)
+
 	// Reset the pot
 	p.Chips = 0
 	p.Eligible = make([]*player.Player, 0)
 }
+
+// award splits chips equally among winners, giving odd chips to the first
+// winners.
+func award(winners []*player.Player, chips int) {
+	chipsPerWinner := chips / len(winners)
+	oddChips := chips % len(winners)
+	for i, winner := range winners {
+		won := chipsPerWinner
+		if i < oddChips {
+			won++
+		}
+		winner.Stack += won
+		log.Printf("Player %s wins %d chips from the pot.\n", winner.Name, won)
+	}
+}
+
+// SidePots splits the chips players committed to a hand into a main pot and
+// side pots. Each all-in player caps a pot at what they committed: the pot
+// takes that much from every player, and only players still in the hand
+// who committed at least as much are eligible for it. Chips committed by
+// players who folded count towards the pots they reach, and chips nobody
+// still in the hand can win go to the last pot someone can. Pots are
+// returned main pot first.
+func SidePots(players []*player.Player) []*Pot {
+	levels := make([]int, 0)
+	for _, player := range players {
+		if player.Committed > 0 && !containsLevel(levels, player.Committed) {
+			levels = append(levels, player.Committed)
+		}
+	}
+	sort.Ints(levels)
+
+	pots := make([]*Pot, 0)
+	dead, previous := 0, 0
+	for _, level := range levels {
+		pot := NewPot()
+		for _, player := range players {
+			pot.Chips += min(player.Committed, level) - min(player.Committed, previous)
+			if player.Active && player.Committed >= level {
+				pot.Eligible = append(pot.Eligible, player)
+			}
+		}
+		previous = level
+
+		switch {
+		case len(pot.Eligible) == 0 && len(pots) == 0:
+			dead += pot.Chips
+		case len(pot.Eligible) == 0:
+			pots[len(pots)-1].Chips += pot.Chips
+		case len(pots) > 0 && sameEligible(pots[len(pots)-1], pot):
+			// A level set by a player who folded does not split the pot
+			pots[len(pots)-1].Chips += pot.Chips
+		default:
+			pot.Chips += dead
+			dead = 0
+			pots = append(pots, pot)
+		}
+	}
+	return pots
+}
+
+func containsLevel(levels []int, level int) bool {
+	for _, l := range levels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
+
+// sameEligible reports whether the same players are eligible for both pots.
+// Eligible players are listed in the same order in every pot.
+func sameEligible(a, b *Pot) bool {
+	if len(a.Eligible) != len(b.Eligible) {
+		return false
+	}
+	for i := range a.Eligible {
+		if a.Eligible[i] != b.Eligible[i] {
+			return false
+		}
+	}
+	return true
+}
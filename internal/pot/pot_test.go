@@ -40,4 +40,87 @@ func TestDistribute(t *testing.T) {
 	if player1.Stack != 2000 || pot.Chips != 0 {
 		t.Error("Failed to distribute the pot to the winner")
 	}
+
+	pot.AddChips(1001)
+	pot.Distribute([]*player.Player{player2, player1})
+	if player2.Stack != 1501 || player1.Stack != 2500 {
+		t.Errorf("Expected the odd chip to go to the first winner, got %d and %d", player2.Stack, player1.Stack)
+	}
+}
+
+func TestDistributeSplit(t *testing.T) {
+	tests := []struct {
+		name     string
+		chips    int
+		high     []int
+		low      []int
+		expected []int
+	}{
+		{"scoop without a low", 101, []int{0}, nil, []int{101, 0, 0}},
+		{"high and low", 101, []int{0}, []int{1}, []int{51, 50, 0}},
+		{"quartered", 100, []int{0}, []int{0, 1}, []int{75, 25, 0}},
+		{"split high, one low", 103, []int{0, 1}, []int{2}, []int{26, 26, 51}},
+	}
+
+	for _, test := range tests {
+		players := []*player.Player{
+			player.NewPlayer("1", "Alice", 0),
+			player.NewPlayer("2", "Bob", 0),
+			player.NewPlayer("3", "Charlie", 0),
+		}
+		winners := func(indexes []int) []*player.Player {
+			selected := make([]*player.Player, 0)
+			for _, index := range indexes {
+				selected = append(selected, players[index])
+			}
+			return selected
+		}
+
+		pot := NewPot()
+		pot.AddChips(test.chips)
+		pot.DistributeSplit(winners(test.high), winners(test.low))
+		for i, expected := range test.expected {
+			if players[i].Stack != expected {
+				t.Errorf("%s: expected %s to have %d chips, got %d", test.name, players[i].Name, expected, players[i].Stack)
+			}
+		}
+		if pot.Chips != 0 {
+			t.Errorf("%s: expected the pot to be empty, got %d", test.name, pot.Chips)
+		}
+	}
+}
+
+func TestSidePots(t *testing.T) {
+	alice := player.NewPlayer("1", "Alice", 0)
+	bob := player.NewPlayer("2", "Bob", 0)
+	charlie := player.NewPlayer("3", "Charlie", 0)
+	dave := player.NewPlayer("4", "Dave", 0)
+	// Alice is all-in for 100 and Bob for 300; Dave folded after putting in
+	// 200, which does not split the side pot
+	alice.Committed, bob.Committed, charlie.Committed, dave.Committed = 100, 300, 500, 200
+	dave.Active = false
+
+	pots := SidePots([]*player.Player{alice, bob, charlie, dave})
+	expected := []struct {
+		chips    int
+		eligible []*player.Player
+	}{
+		{400, []*player.Player{alice, bob, charlie}},
+		{500, []*player.Player{bob, charlie}},
+		{200, []*player.Player{charlie}},
+	}
+	if len(pots) != len(expected) {
+		t.Fatalf("Expected %d pots, got %d", len(expected), len(pots))
+	}
+	for i, pot := range pots {
+		if pot.Chips != expected[i].chips || len(pot.Eligible) != len(expected[i].eligible) {
+			t.Errorf("Pot %d: expected %d chips for %d players, got %d for %d", i, expected[i].chips, len(expected[i].eligible), pot.Chips, len(pot.Eligible))
+			continue
+		}
+		for j, player := range pot.Eligible {
+			if player != expected[i].eligible[j] {
+				t.Errorf("Pot %d: expected %s to be eligible, got %s", i, expected[i].eligible[j].Name, player.Name)
+			}
+		}
+	}
 }
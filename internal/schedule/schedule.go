@@ -0,0 +1,261 @@
+package schedule
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Level is one step of a blind schedule. A level lasts for its Duration, or
+// for its number of Hands, whichever is set; a break has a Duration only.
+type Level struct {
+	SmallBlind   int           // Small blind amount
+	BigBlind     int           // Big blind amount
+	Ante         int           // Ante posted by every player dealt in
+	BigBlindAnte int           // Single ante posted by the big blind for the whole table
+	Duration     time.Duration // How long the level lasts
+	Hands        int           // How many hands the level lasts
+	Break        bool          // Whether no hands are played during the level
+}
+
+// Schedule steps through blind levels as time passes and hands are played.
+// Levels only change between hands; the last level lasts until the end.
+type Schedule struct {
+	Levels []Level
+	Level  int              // Index of the current level
+	Clock  func() time.Time // Current time; tests replace it to fast-forward
+
+	started      bool
+	levelStarted time.Time
+	handsPlayed  int // Hands played during the current level
+}
+
+func NewSchedule(levels []Level) *Schedule {
+	return &Schedule{
+		Levels: levels,
+		Clock:  time.Now,
+	}
+}
+
+// Load reads a schedule from a JSON or YAML file, chosen by its extension.
+func Load(path string) (*Schedule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	switch filepath.Ext(path) {
+	case ".json":
+		return ParseJSON(data)
+	case ".yaml", ".yml":
+		return ParseYAML(data)
+	default:
+		return nil, fmt.Errorf("unknown schedule format: %s", path)
+	}
+}
+
+// jsonLevel is a Level as written in a JSON schedule file, with its
+// duration as a string such as "15m".
+type jsonLevel struct {
+	SmallBlind   int    `json:"smallBlind"`
+	BigBlind     int    `json:"bigBlind"`
+	Ante         int    `json:"ante"`
+	BigBlindAnte int    `json:"bigBlindAnte"`
+	Duration     string `json:"duration"`
+	Hands        int    `json:"hands"`
+	Break        bool   `json:"break"`
+}
+
+// ParseJSON reads a schedule of the form
+//
+//	{"levels": [{"smallBlind": 25, "bigBlind": 50, "duration": "15m"}, {"break": true, "duration": "5m"}]}
+//
+// Unknown keys are rejected.
+func ParseJSON(data []byte) (*Schedule, error) {
+	var file struct {
+		Levels []jsonLevel `json:"levels"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&file); err != nil {
+		return nil, jsonError(data, err)
+	}
+
+	levels := make([]Level, 0, len(file.Levels))
+	for i, fields := range file.Levels {
+		level := Level{
+			SmallBlind:   fields.SmallBlind,
+			BigBlind:     fields.BigBlind,
+			Ante:         fields.Ante,
+			BigBlindAnte: fields.BigBlindAnte,
+			Hands:        fields.Hands,
+			Break:        fields.Break,
+		}
+		if fields.Duration != "" {
+			duration, err := time.ParseDuration(fields.Duration)
+			if err != nil {
+				return nil, fmt.Errorf("level %d: invalid duration %q", i+1, fields.Duration)
+			}
+			level.Duration = duration
+		}
+		levels = append(levels, level)
+	}
+	return newValidSchedule(levels)
+}
+
+// jsonError adds the line and column at which decoding failed to a JSON
+// error, when it is known.
+func jsonError(data []byte, err error) error {
+	var offset int64
+	switch err := err.(type) {
+	case *json.SyntaxError:
+		offset = err.Offset
+	case *json.UnmarshalTypeError:
+		offset = err.Offset
+	default:
+		return err
+	}
+	before := data[:min(int(offset), len(data))]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := len(before) - bytes.LastIndexByte(before, '\n')
+	return fmt.Errorf("line %d, column %d: %v", line, column, err)
+}
+
+// Validate checks that every level can be played and will end.
+func (s *Schedule) Validate() error {
+	if len(s.Levels) == 0 {
+		return fmt.Errorf("schedule has no levels")
+	}
+	playable := false
+	for i, level := range s.Levels {
+		last := i == len(s.Levels)-1
+		switch {
+		case level.Break && level.Duration <= 0:
+			return fmt.Errorf("level %d: a break needs a duration", i+1)
+		case level.Break && level.Hands > 0:
+			return fmt.Errorf("level %d: a break cannot last a number of hands", i+1)
+		case !level.Break && level.BigBlind <= 0:
+			return fmt.Errorf("level %d: big blind must be positive", i+1)
+		case !level.Break && level.SmallBlind > level.BigBlind:
+			return fmt.Errorf("level %d: small blind %d is bigger than the big blind %d", i+1, level.SmallBlind, level.BigBlind)
+		case level.Duration > 0 && level.Hands > 0:
+			return fmt.Errorf("level %d: set a duration or a number of hands, not both", i+1)
+		case !last && level.Duration <= 0 && level.Hands <= 0:
+			return fmt.Errorf("level %d: needs a duration or a number of hands", i+1)
+		}
+		playable = playable || !level.Break
+	}
+	if !playable {
+		return fmt.Errorf("schedule has only breaks")
+	}
+	return nil
+}
+
+// Start starts the clock on the first level. Advance starts it if it has
+// not been started.
+func (s *Schedule) Start() {
+	s.started = true
+	s.Level = 0
+	s.levelStarted = s.Clock()
+	s.handsPlayed = 0
+}
+
+// Advance moves on to the level that should be in play now. It is called
+// between hands.
+func (s *Schedule) Advance() {
+	if !s.started {
+		s.Start()
+	}
+	for s.Level < len(s.Levels)-1 && s.levelOver() {
+		level := s.Levels[s.Level]
+		if level.Duration > 0 {
+			s.levelStarted = s.levelStarted.Add(level.Duration)
+		} else {
+			s.levelStarted = s.Clock()
+		}
+		s.Level++
+		s.handsPlayed = 0
+
+		next := s.Current()
+		if next.Break {
+			log.Printf("Break for %v.\n", next.Duration)
+		} else {
+			log.Printf("Blinds are now %d/%d, ante %d.\n", next.SmallBlind, next.BigBlind, next.Ante+next.BigBlindAnte)
+		}
+	}
+}
+
+// HandPlayed counts a finished hand towards the current level.
+func (s *Schedule) HandPlayed() {
+	s.handsPlayed++
+}
+
+// Current returns the level in play, which may be a break.
+func (s *Schedule) Current() Level {
+	return s.Levels[s.Level]
+}
+
+// OnBreak reports whether the current level is a break.
+func (s *Schedule) OnBreak() bool {
+	return s.Current().Break
+}
+
+// Blinds returns the level whose blinds and antes apply: the current level,
+// or during a break the level before it, or the first level after it when
+// the schedule opens with a break.
+func (s *Schedule) Blinds() Level {
+	for i := s.Level; i >= 0; i-- {
+		if !s.Levels[i].Break {
+			return s.Levels[i]
+		}
+	}
+	for i := s.Level; i < len(s.Levels); i++ {
+		if !s.Levels[i].Break {
+			return s.Levels[i]
+		}
+	}
+	return s.Current()
+}
+
+// TimeRemaining returns how long the current level has left, or 0 for a
+// level counted in hands or the last level.
+func (s *Schedule) TimeRemaining() time.Duration {
+	level := s.Current()
+	if level.Duration <= 0 || s.Level == len(s.Levels)-1 {
+		return 0
+	}
+	remaining := s.levelStarted.Add(level.Duration).Sub(s.Clock())
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// HandsRemaining returns how many hands the current level has left, or 0
+// for a timed level or the last level.
+func (s *Schedule) HandsRemaining() int {
+	level := s.Current()
+	if level.Hands <= 0 || s.Level == len(s.Levels)-1 || s.handsPlayed >= level.Hands {
+		return 0
+	}
+	return level.Hands - s.handsPlayed
+}
+
+func (s *Schedule) levelOver() bool {
+	level := s.Current()
+	if level.Duration > 0 {
+		return !s.Clock().Before(s.levelStarted.Add(level.Duration))
+	}
+	return s.handsPlayed >= level.Hands
+}
+
+func newValidSchedule(levels []Level) (*Schedule, error) {
+	s := NewSchedule(levels)
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
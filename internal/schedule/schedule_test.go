@@ -0,0 +1,162 @@
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	jsonSchedule := `{"levels": [
+		{"smallBlind": 25, "bigBlind": 50, "duration": "15m"},
+		{"break": true, "duration": "5m"},
+		{"smallBlind": 500000, "bigBlind": 1000000, "bigBlindAnte": 1000000, "hands": 10}
+	]}`
+	yamlSchedule := `
+# Turbo structure
+levels:
+  - smallBlind: 25
+    bigBlind: 50
+    duration: 15m
+  - break: true
+    duration: "5m"
+  - smallBlind: 500000
+    bigBlind: 1000000
+    bigBlindAnte: 1000000 # big blind ante
+    hands: 10
+`
+	want := []Level{
+		{SmallBlind: 25, BigBlind: 50, Duration: 15 * time.Minute},
+		{Break: true, Duration: 5 * time.Minute},
+		{SmallBlind: 500000, BigBlind: 1000000, BigBlindAnte: 1000000, Hands: 10},
+	}
+
+	tests := []struct {
+		name  string
+		parse func([]byte) (*Schedule, error)
+		data  string
+	}{
+		{"JSON", ParseJSON, jsonSchedule},
+		{"YAML", ParseYAML, yamlSchedule},
+	}
+	for _, test := range tests {
+		s, err := test.parse([]byte(test.data))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if len(s.Levels) != len(want) {
+			t.Errorf("%s: expected %d levels, got %d", test.name, len(want), len(s.Levels))
+			continue
+		}
+		for i := range want {
+			if s.Levels[i] != want[i] {
+				t.Errorf("%s: expected level %d to be %+v, got %+v", test.name, i+1, want[i], s.Levels[i])
+			}
+		}
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func([]byte) (*Schedule, error)
+		data  string
+		line  int
+	}{
+		{"JSON unknown key", ParseJSON, `{"levels": [{"smallBlind": 25, "bigBlind": 50, "speed": 2}]}`, 0},
+		{"JSON blind not a number", ParseJSON, "{\"levels\": [\n{\"smallBlind\": \"lots\", \"bigBlind\": 50}]}", 2},
+		{"JSON invalid duration", ParseJSON, `{"levels": [{"smallBlind": 25, "bigBlind": 50, "duration": "soon"}]}`, 0},
+		{"YAML blind not a number", ParseYAML, "levels:\n  - smallBlind: lots\n", 2},
+		{"YAML flow list", ParseYAML, "levels: [{smallBlind: 25, bigBlind: 50}]\n", 1},
+		{"YAML flow map", ParseYAML, "levels:\n  - {smallBlind: 25, bigBlind: 50}\n", 2},
+		{"YAML quoted key", ParseYAML, "levels:\n  - \"smallBlind\": 25\n    bigBlind: 50\n", 2},
+		{"YAML anchor", ParseYAML, "levels:\n  - smallBlind: &blind 25\n    bigBlind: 50\n", 2},
+		{"YAML alias", ParseYAML, "levels:\n  - smallBlind: 25\n    bigBlind: *blind\n", 3},
+		{"YAML tag", ParseYAML, "levels:\n  - smallBlind: !!int 25\n    bigBlind: 50\n", 2},
+		{"YAML block scalar", ParseYAML, "levels:\n  - bigBlind: 50\n    duration: >\n      15m\n", 3},
+		{"YAML multi-line value", ParseYAML, "levels:\n  - bigBlind: 50\n    duration:\n      15m\n", 3},
+		{"YAML unterminated quote", ParseYAML, "levels:\n  - bigBlind: 50\n    duration: \"15\n      m\"\n", 3},
+		{"YAML nested list", ParseYAML, "levels:\n  - bigBlind: 50\n    - hands: 10\n", 3},
+		{"YAML tab indent", ParseYAML, "levels:\n\t- bigBlind: 50\n", 2},
+		{"YAML document marker", ParseYAML, "---\nlevels:\n  - bigBlind: 50\n", 1},
+		{"YAML second key", ParseYAML, "levels:\n  - bigBlind: 50\nplayers: 9\n", 3},
+	}
+	for _, test := range tests {
+		_, err := test.parse([]byte(test.data))
+		if err == nil {
+			t.Errorf("%s: expected error", test.name)
+			continue
+		}
+		if test.line > 0 && !strings.HasPrefix(err.Error(), fmt.Sprintf("line %d,", test.line)) {
+			t.Errorf("%s: expected an error on line %d, got %v", test.name, test.line, err)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		levels []Level
+	}{
+		{"no levels", []Level{}},
+		{"no big blind", []Level{{SmallBlind: 25}}},
+		{"endless level", []Level{{SmallBlind: 25, BigBlind: 50}, {SmallBlind: 50, BigBlind: 100}}},
+		{"break without duration", []Level{{Break: true}, {SmallBlind: 25, BigBlind: 50}}},
+		{"duration and hands", []Level{{SmallBlind: 25, BigBlind: 50, Duration: time.Minute, Hands: 5}}},
+	}
+	for _, test := range tests {
+		if err := NewSchedule(test.levels).Validate(); err == nil {
+			t.Errorf("Expected error for %s", test.name)
+		}
+	}
+}
+
+func TestAdvance(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := NewSchedule([]Level{
+		{SmallBlind: 25, BigBlind: 50, Duration: 10 * time.Minute},
+		{Break: true, Duration: 5 * time.Minute},
+		{SmallBlind: 50, BigBlind: 100, Hands: 2},
+		{SmallBlind: 100, BigBlind: 200},
+	})
+	s.Clock = func() time.Time { return now }
+	s.Start()
+
+	now = now.Add(9 * time.Minute)
+	s.Advance()
+	if s.Level != 0 || s.TimeRemaining() != time.Minute {
+		t.Errorf("Expected level 0 with a minute left, got level %d with %v", s.Level, s.TimeRemaining())
+	}
+
+	// A long hand does not delay the next level's start.
+	now = now.Add(3 * time.Minute)
+	s.Advance()
+	if !s.OnBreak() || s.Blinds().BigBlind != 50 || s.TimeRemaining() != 3*time.Minute {
+		t.Errorf("Expected a break with 3 minutes left at 25/50, got level %d with %v", s.Level, s.TimeRemaining())
+	}
+
+	now = now.Add(3 * time.Minute)
+	s.Advance()
+	if s.Level != 2 || s.HandsRemaining() != 2 {
+		t.Errorf("Expected level 2 with 2 hands left, got level %d with %d", s.Level, s.HandsRemaining())
+	}
+
+	s.HandPlayed()
+	s.Advance()
+	if s.Level != 2 {
+		t.Errorf("Expected level 2 after one hand, got level %d", s.Level)
+	}
+	s.HandPlayed()
+	s.Advance()
+	if s.Current().BigBlind != 200 {
+		t.Errorf("Expected 100/200 after two hands, got %d/%d", s.Current().SmallBlind, s.Current().BigBlind)
+	}
+
+	now = now.Add(24 * time.Hour)
+	s.Advance()
+	if s.Level != 3 {
+		t.Errorf("Expected the last level to last until the end, got level %d", s.Level)
+	}
+}
@@ -0,0 +1,185 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseYAML reads a schedule written in the subset of YAML used for
+// schedule files:
+//
+//	# Comments start with # at the start of a line or after a space
+//	levels:
+//	  - smallBlind: 25
+//	    bigBlind: 50
+//	    duration: 15m
+//	  - break: true
+//	    duration: "5m"
+//
+// The file holds a single "levels" key whose value is a block list, one
+// "- " item per level at the same indentation. Each item is a block map of
+// "key: value" pairs, the first on the item's line or the line after it and
+// the rest lined up under it. Keys are plain level field names. Values are
+// plain scalars, or scalars in single or double quotes without escapes,
+// that fit on one line.
+//
+// Anything else YAML allows is rejected with its line and column: tabs
+// used for indentation, document markers, flow lists and maps ([...] and
+// {...}), quoted keys, anchors, aliases, tags, block scalars (| and >),
+// values continued over several lines and nested collections.
+func ParseYAML(data []byte) (*Schedule, error) {
+	levels := make([]Level, 0)
+	inLevels := false
+	itemIndent, keyIndent := -1, -1
+
+	for number, line := range strings.Split(string(data), "\n") {
+		errorAt := func(column int, format string, args ...interface{}) error {
+			return fmt.Errorf("line %d, column %d: %s", number+1, column+1, fmt.Sprintf(format, args...))
+		}
+
+		line = strings.TrimRight(stripComment(line), " \t\r")
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if indent == len(line) {
+			continue
+		}
+		if line[indent] == '\t' {
+			return nil, errorAt(indent, "tabs cannot indent YAML")
+		}
+		content := line[indent:]
+
+		switch {
+		case content == "---" || content == "...":
+			return nil, errorAt(indent, "document markers are not supported")
+		case indent == 0 && !strings.HasPrefix(content, "-"):
+			key, value, _ := strings.Cut(content, ":")
+			if key != "levels" || inLevels {
+				return nil, errorAt(0, "unexpected key %q: a schedule has a single levels key", key)
+			}
+			if strings.TrimSpace(value) != "" {
+				return nil, errorAt(len(key)+1, "levels must be a block list with one level per item")
+			}
+			inLevels = true
+		case !inLevels:
+			return nil, errorAt(indent, "expected levels")
+		case content == "-" || strings.HasPrefix(content, "- "):
+			if itemIndent == -1 {
+				itemIndent = indent
+			}
+			if indent != itemIndent {
+				return nil, errorAt(indent, "list items must be lined up at column %d", itemIndent+1)
+			}
+			levels = append(levels, Level{})
+			rest := strings.TrimLeft(content[1:], " ")
+			keyIndent = -1
+			if rest == "" {
+				continue
+			}
+			keyIndent = len(line) - len(rest)
+			if err := parsePair(&levels[len(levels)-1], rest, keyIndent, errorAt); err != nil {
+				return nil, err
+			}
+		default:
+			if len(levels) == 0 || indent <= itemIndent {
+				return nil, errorAt(indent, "expected a list item")
+			}
+			if keyIndent == -1 {
+				keyIndent = indent
+			}
+			if indent != keyIndent {
+				return nil, errorAt(indent, "expected a key at column %d; nested and multi-line values are not supported", keyIndent+1)
+			}
+			if err := parsePair(&levels[len(levels)-1], content, indent, errorAt); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return newValidSchedule(levels)
+}
+
+// stripComment removes a comment from a line of YAML.
+func stripComment(line string) string {
+	for i, c := range line {
+		if c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t') {
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// parsePair sets the level field named by a "key: value" pair starting at
+// the given column.
+func parsePair(level *Level, text string, column int, errorAt func(int, string, ...interface{}) error) error {
+	if err := checkScalar(text, column, errorAt); err != nil {
+		return err
+	}
+	if text[0] == '"' || text[0] == '\'' {
+		return errorAt(column, "quoted keys are not supported")
+	}
+	key, value, ok := strings.Cut(text, ":")
+	if !ok || (value != "" && value[0] != ' ') {
+		return errorAt(column, "expected key: value")
+	}
+	valueColumn := column + len(key) + 1 + len(value) - len(strings.TrimLeft(value, " "))
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return errorAt(valueColumn, "%s has no value; nested and multi-line values are not supported", key)
+	}
+	if err := checkScalar(value, valueColumn, errorAt); err != nil {
+		return err
+	}
+	if quote := value[0]; quote == '"' || quote == '\'' {
+		if len(value) < 2 || value[len(value)-1] != quote {
+			return errorAt(valueColumn, "unterminated quoted value; multi-line values are not supported")
+		}
+		value = value[1 : len(value)-1]
+	}
+	if err := setField(level, key, value); err != nil {
+		return errorAt(column, "%v", err)
+	}
+	return nil
+}
+
+// checkScalar rejects the YAML features a key or value may start with that
+// schedule files do not support.
+func checkScalar(text string, column int, errorAt func(int, string, ...interface{}) error) error {
+	switch text[0] {
+	case '[', '{':
+		return errorAt(column, "flow lists and maps are not supported")
+	case '&', '*':
+		return errorAt(column, "anchors and aliases are not supported")
+	case '!':
+		return errorAt(column, "tags are not supported")
+	case '|', '>':
+		return errorAt(column, "block scalars are not supported")
+	}
+	return nil
+}
+
+// setField sets the level field named by a schedule file key.
+func setField(level *Level, key, value string) error {
+	var err error
+	switch key {
+	case "smallBlind":
+		level.SmallBlind, err = strconv.Atoi(value)
+	case "bigBlind":
+		level.BigBlind, err = strconv.Atoi(value)
+	case "ante":
+		level.Ante, err = strconv.Atoi(value)
+	case "bigBlindAnte":
+		level.BigBlindAnte, err = strconv.Atoi(value)
+	case "duration":
+		level.Duration, err = time.ParseDuration(value)
+	case "hands":
+		level.Hands, err = strconv.Atoi(value)
+	case "break":
+		level.Break, err = strconv.ParseBool(value)
+	default:
+		return fmt.Errorf("unknown key %q", key)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s %q", key, value)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package sim
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+
+	"github.com/prfc0/aksha/internal/bot"
+	"github.com/prfc0/aksha/internal/game"
+	"github.com/prfc0/aksha/internal/player"
+)
+
+// Entrant is a player in a simulation and the strategy they play.
+type Entrant struct {
+	Name     string                            // Name the player's results are reported under
+	Strategy func(rng *rand.Rand) bot.Strategy // Creates the player's strategy at each table
+}
+
+// Config describes a simulation of bot-vs-bot No-Limit Hold'em.
+type Config struct {
+	Entrants   []Entrant // Players seated at every table
+	Hands      int       // Hands played in all, shared between the tables
+	Tables     int       // Tables played at the same time; results do not depend on it
+	Seed       int64     // Seed of the shuffles and strategies; the same seed plays the same hands
+	SmallBlind int       // Small blind
+	BigBlind   int       // Big blind
+	Stack      int       // Chips every player starts each hand with
+}
+
+// Result is how one entrant fared over a simulation.
+type Result struct {
+	Name     string  // Name of the entrant
+	Hands    int     // Hands the entrant was dealt
+	BBPer100 float64 // Big blinds won per 100 hands
+	CI95     float64 // Half-width of the 95% confidence interval of BBPer100
+}
+
+func (r Result) String() string {
+	return fmt.Sprintf("%s: %+.2f ± %.2f bb/100 over %d hands", r.Name, r.BBPer100, r.CI95, r.Hands)
+}
+
+// tally sums an entrant's winnings, in big blinds, hand by hand.
+type tally struct {
+	hands      int
+	sum, sumSq float64
+}
+
+func (t *tally) add(other tally) {
+	t.hands += other.hands
+	t.sum += other.sum
+	t.sumSq += other.sumSq
+}
+
+// batchHands is the most hands in a batch. The hands are played in batches,
+// each with its own seed, so that how they are shared between the tables
+// cannot change them.
+const batchHands = 100
+
+// Run plays the simulation and returns each entrant's results in the order
+// they were given. The hands are split into batches of whole orbits of the
+// button, seeded in turn by a generator seeded with the simulation's seed,
+// which the tables take one at a time and play at once; only the last
+// batch may stop partway through an orbit. Every player's stack is reset between hands
+// so that results are measured in big blinds per hand.
+func Run(config Config) ([]Result, error) {
+	if len(config.Entrants) < 2 {
+		return nil, fmt.Errorf("a simulation needs at least two entrants, not %d", len(config.Entrants))
+	}
+	if config.Hands <= 0 || config.Tables <= 0 {
+		return nil, fmt.Errorf("cannot play %d hands at %d tables", config.Hands, config.Tables)
+	}
+	if config.BigBlind <= 0 || config.Stack < config.BigBlind {
+		return nil, fmt.Errorf("cannot play with a big blind of %d and stacks of %d", config.BigBlind, config.Stack)
+	}
+
+	size := max(batchHands/len(config.Entrants), 1) * len(config.Entrants)
+	batches := (config.Hands + size - 1) / size
+	seeds := make([]int64, batches)
+	seeder := rand.New(rand.NewSource(config.Seed))
+	for i := range seeds {
+		seeds[i] = seeder.Int63()
+	}
+
+	queue := make(chan int, batches)
+	for i := 0; i < batches; i++ {
+		queue <- i
+	}
+	close(queue)
+
+	tallies := make([][]tally, batches)
+	errs := make([]error, batches)
+	var wg sync.WaitGroup
+	for table := 0; table < config.Tables; table++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range queue {
+				hands := min(size, config.Hands-batch*size)
+				tallies[batch], errs[batch] = playBatch(config, seeds[batch], hands)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Batches are summed in order, so the totals come out the same to the
+	// last bit however the tables shared them
+	results := make([]Result, len(config.Entrants))
+	for i, entrant := range config.Entrants {
+		var total tally
+		for _, batch := range tallies {
+			total.add(batch[i])
+		}
+		results[i] = result(entrant.Name, total)
+	}
+	return results, nil
+}
+
+// playBatch plays a batch of hands at a fresh table and returns each
+// entrant's winnings. After every orbit of the button the players all move
+// up one seat, so each entrant plays every position once an orbit and every
+// seat once in as many orbits as there are entrants.
+func playBatch(config Config, seed int64, hands int) ([]tally, error) {
+	rng := rand.New(rand.NewSource(seed))
+	players := make([]*player.Player, len(config.Entrants))
+	strategies := make(map[string]bot.Strategy)
+	for i, entrant := range config.Entrants {
+		players[i] = player.NewPlayer(fmt.Sprint(i), entrant.Name, config.Stack)
+		strategies[players[i].ID] = entrant.Strategy(rng)
+	}
+	g := game.NewGame(append([]*player.Player{}, players...), config.SmallBlind, config.BigBlind)
+	g.Seed(rng.Int63())
+	g.StrictChips = true
+
+	tallies := make([]tally, len(players))
+	for hand := 0; hand < hands; hand++ {
+		if orbit := hand / len(players); hand%len(players) == 0 {
+			for i := range g.Players {
+				g.Players[i] = players[(i+orbit)%len(players)]
+			}
+		}
+		for _, p := range players {
+			p.Stack = config.Stack
+		}
+
+		if err := bot.PlayHand(g, strategies); err != nil {
+			return nil, err
+		}
+		for i, p := range players {
+			won := float64(p.Stack-config.Stack) / float64(config.BigBlind)
+			tallies[i].hands++
+			tallies[i].sum += won
+			tallies[i].sumSq += won * won
+		}
+	}
+	return tallies, nil
+}
+
+// result turns an entrant's winnings into big blinds per 100 hands, with a
+// normal 95% confidence interval from the spread of their results.
+func result(name string, t tally) Result {
+	r := Result{Name: name, Hands: t.hands}
+	if t.hands == 0 {
+		return r
+	}
+	mean := t.sum / float64(t.hands)
+	r.BBPer100 = 100 * mean
+	if t.hands > 1 {
+		variance := (t.sumSq - float64(t.hands)*mean*mean) / float64(t.hands-1)
+		r.CI95 = 100 * 1.96 * math.Sqrt(math.Max(variance, 0)/float64(t.hands))
+	}
+	return r
+}
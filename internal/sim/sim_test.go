@@ -0,0 +1,173 @@
+package sim
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"math/rand"
+	"os"
+	"testing"
+
+	"github.com/prfc0/aksha/internal/action"
+	"github.com/prfc0/aksha/internal/bot"
+	"github.com/prfc0/aksha/internal/game"
+)
+
+func TestMain(m *testing.M) {
+	// Every hand logs every action
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+func newConfig() Config {
+	return Config{
+		Entrants: []Entrant{
+			{"Caller", func(rng *rand.Rand) bot.Strategy { return bot.AlwaysCall{} }},
+			{"Random", func(rng *rand.Rand) bot.Strategy { return bot.NewRandom(rng) }},
+			{"Random 2", func(rng *rand.Rand) bot.Strategy { return bot.NewRandom(rng) }},
+		},
+		Hands:      300,
+		Tables:     4,
+		Seed:       42,
+		SmallBlind: 1,
+		BigBlind:   2,
+		Stack:      200,
+	}
+}
+
+func TestRun(t *testing.T) {
+	first, err := Run(newConfig())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	second, _ := Run(newConfig())
+
+	total := 0.0
+	for i, result := range first {
+		if result != second[i] {
+			t.Errorf("Expected the same seed to give the same results, got %v and %v", result, second[i])
+		}
+		if result.Hands != 300 || result.CI95 <= 0 {
+			t.Errorf("Expected 300 hands with a confidence interval, got %v", result)
+		}
+		total += result.BBPer100
+	}
+	if math.Abs(total) > 1e-9 {
+		t.Errorf("Expected the winnings to sum to zero, got %f bb/100", total)
+	}
+
+	config := newConfig()
+	config.Seed = 43
+	if other, _ := Run(config); other[0] == first[0] {
+		t.Errorf("Expected a different seed to play different hands")
+	}
+}
+
+func TestRunTables(t *testing.T) {
+	// The same seed plays the same hands however many tables share them
+	want, _ := Run(newConfig())
+	for _, tables := range []int{1, 2, 7} {
+		config := newConfig()
+		config.Tables = tables
+		got, err := Run(config)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%d tables: expected %v, got %v", tables, want[i], got[i])
+			}
+		}
+	}
+}
+
+// seating checks or calls like AlwaysCall, and counts, for each player, the
+// hands they sat in each seat and each number of seats after the first
+// player to act.
+type seating struct{ seats, positions map[string][]int }
+
+func (s seating) Act(view *game.View, choices []game.Choice) *action.Action {
+	if len(view.History) == 0 {
+		first := 0
+		for i, p := range view.Players {
+			if p.ID == view.PlayerID {
+				first = i
+			}
+		}
+		for i, p := range view.Players {
+			s.seats[p.Name][i]++
+			s.positions[p.Name][(i-first+len(view.Players))%len(view.Players)]++
+		}
+	}
+	return bot.AlwaysCall{}.Act(view, choices)
+}
+
+func TestRunRotatesSeats(t *testing.T) {
+	for _, entrants := range []int{2, 3, 4, 6} {
+		config := newConfig()
+		config.Entrants = nil
+		config.Tables = 1
+		recorded := seating{make(map[string][]int), make(map[string][]int)}
+		for i := 0; i < entrants; i++ {
+			name := fmt.Sprint("Player ", i)
+			recorded.seats[name] = make([]int, entrants)
+			recorded.positions[name] = make([]int, entrants)
+			config.Entrants = append(config.Entrants, Entrant{name, func(rng *rand.Rand) bot.Strategy { return recorded }})
+		}
+		// A single batch of whole orbits
+		config.Hands = batchHands / entrants * entrants
+		if _, err := Run(config); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		orbits := config.Hands / entrants
+		for name, counts := range recorded.positions {
+			for position, count := range counts {
+				if count != orbits {
+					t.Errorf("%d entrants: expected %s %d seats after the first to act %d times, got %d", entrants, name, position, orbits, count)
+				}
+			}
+		}
+		// Moving up a seat an orbit, every player sits in each seat for the
+		// same number of orbits, give or take one
+		for name, counts := range recorded.seats {
+			for seat, count := range counts {
+				if count < orbits/entrants*entrants || count > (orbits/entrants+1)*entrants {
+					t.Errorf("%d entrants: expected %s in seat %d for %d or %d orbits, got %d hands", entrants, name, seat, orbits/entrants, orbits/entrants+1, count)
+				}
+			}
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+	}{
+		{"one entrant", func(c *Config) { c.Entrants = c.Entrants[:1] }},
+		{"no hands", func(c *Config) { c.Hands = 0 }},
+		{"no tables", func(c *Config) { c.Tables = 0 }},
+		{"short stacks", func(c *Config) { c.Stack = 1 }},
+	}
+	for _, tt := range tests {
+		config := newConfig()
+		tt.modify(&config)
+		if _, err := Run(config); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
+
+func TestResult(t *testing.T) {
+	// Winning 1 and losing 1 big blind in turn averages 0 with a standard
+	// deviation of about 1
+	r := result("Alice", tally{hands: 100, sum: 0, sumSq: 100})
+	if r.BBPer100 != 0 || math.Abs(r.CI95-100*1.96*math.Sqrt(100.0/99/100)) > 1e-9 {
+		t.Errorf("Unexpected result: %v", r)
+	}
+	if r := result("Bob", tally{}); r.Hands != 0 || r.BBPer100 != 0 {
+		t.Errorf("Expected no result without hands, got %v", r)
+	}
+}
@@ -3,55 +3,424 @@ package table
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/prfc0/aksha/internal/player"
 )
 
 type Table struct {
-	Players        []*player.Player // List of players at the table
-	DealerPosition int              // Position of the dealer button
-	MaxPlayers     int              // Maximum number of players allowed at the table
+	Seats               []*player.Player // Player in each numbered seat, nil if the seat is empty
+	Players             []*player.Player // List of seated players, in seat order
+	DealerPosition      int              // Seat of the dealer button
+	SmallBlindSeat      int              // Seat of the small blind, which is dead if nobody there is playing
+	BigBlindSeat        int              // Seat of the big blind, or -1 before the first hand
+	MaxPlayers          int              // Maximum number of players allowed at the table
+	MaxOrbitsSittingOut int              // Orbits a player may sit out before losing the seat; 0 never removes them
+	MinBuyIn            int              // Smallest stack a player may sit down with; 0 for no minimum
+	MaxBuyIn            int              // Largest stack a player may buy or top up to; 0 for no maximum
+	RatholeWindow       time.Duration    // How long a player who leaves must return with at least the stack they left with
+	HandInProgress      bool             // Whether a hand is being played; chips added now are queued
+	SeatOpened          func(seat int)   // Called when a player stands up, if set
+
+	dealtIn          []*player.Player     // Players dealt into the current hand
+	orbitsSittingOut map[string]int       // Times the big blind has passed each player sitting out
+	departures       map[string]departure // Stack and time each player last left the table
+	reserved         map[int]string       // Seats held for a player, by seat
+	clock            func() time.Time     // Current time, replaced in tests
+}
+
+// departure records a player leaving the table, to stop them from
+// rejoining with fewer chips straight away.
+type departure struct {
+	stack int
+	at    time.Time
 }
 
 func NewTable(maxPlayers int) *Table {
 	return &Table{
-		Players:        make([]*player.Player, 0),
-		DealerPosition: 0,
-		MaxPlayers:     maxPlayers,
+		Seats:            make([]*player.Player, maxPlayers),
+		Players:          make([]*player.Player, 0),
+		DealerPosition:   0,
+		SmallBlindSeat:   -1,
+		BigBlindSeat:     -1,
+		MaxPlayers:       maxPlayers,
+		orbitsSittingOut: make(map[string]int),
+		departures:       make(map[string]departure),
+		reserved:         make(map[int]string),
+		clock:            time.Now,
 	}
 }
 
+// AddPlayer seats a player with their current stack in the lowest numbered
+// empty seat.
 func (t *Table) AddPlayer(player *player.Player) error {
-	if len(t.Players) >= t.MaxPlayers {
-		return fmt.Errorf("table is full: max %d players allowed", t.MaxPlayers)
+	if seats := t.EmptySeats(); len(seats) > 0 {
+		return t.SitDown(seats[0], player, player.Stack)
+	}
+	return fmt.Errorf("table is full: max %d players allowed", t.MaxPlayers)
+}
+
+// SitDown seats a player in the given seat with a stack of buyIn chips. The
+// buy-in must be within the table's limits, except that a player returning
+// within the rathole window must bring back at least the stack they left
+// with, even above the maximum.
+func (t *Table) SitDown(seat int, player *player.Player, buyIn int) error {
+	if seat < 0 || seat >= t.MaxPlayers {
+		return fmt.Errorf("seat %d does not exist: table has %d seats", seat, t.MaxPlayers)
+	}
+	if t.Seats[seat] != nil {
+		return fmt.Errorf("seat %d is taken by %s", seat, t.Seats[seat].Name)
+	}
+	if playerID, ok := t.reserved[seat]; ok && playerID != player.ID {
+		return fmt.Errorf("seat %d is reserved", seat)
 	}
-	t.Players = append(t.Players, player)
-	log.Printf("Player %s joined the table.\n", player.Name)
+	if t.findPlayer(player.ID) != nil {
+		return fmt.Errorf("player %s is already seated", player.Name)
+	}
+	if buyIn <= 0 {
+		return fmt.Errorf("buy-in must be positive, got %d", buyIn)
+	}
+	minBuyIn, maxBuyIn := t.buyInLimits(player.ID)
+	if buyIn < minBuyIn {
+		return fmt.Errorf("buy-in of %d is below the minimum of %d", buyIn, minBuyIn)
+	}
+	if maxBuyIn > 0 && buyIn > maxBuyIn {
+		return fmt.Errorf("buy-in of %d is above the maximum of %d", buyIn, maxBuyIn)
+	}
+
+	player.Seat = seat
+	player.Stack = buyIn
+	t.Seats[seat] = player
+	delete(t.reserved, seat)
+	t.updatePlayers()
+	log.Printf("Player %s joined the table in seat %d.\n", player.Name, player.Seat)
 	return nil
 }
 
-func (t *Table) RemovePlayer(playerID string) {
-	for i, player := range t.Players {
-		if player.ID == playerID {
-			t.Players = append(t.Players[:i], t.Players[i+1:]...)
-			log.Printf("Player %s left the table.\n", player.Name)
-			return
+// StandUp removes a player from their seat. The seat is found at this
+// table, so a player who has already sat down at another table, as when
+// moving tables in a tournament, leaves the right one.
+func (t *Table) StandUp(playerID string) error {
+	for seat, player := range t.Seats {
+		if player == nil || player.ID != playerID {
+			continue
+		}
+		t.Seats[seat] = nil
+		delete(t.orbitsSittingOut, playerID)
+		t.departures[playerID] = departure{stack: player.Stack + player.PendingChips, at: t.clock()}
+		t.updatePlayers()
+		log.Printf("Player %s left the table.\n", player.Name)
+		if t.SeatOpened != nil {
+			t.SeatOpened(seat)
+		}
+		return nil
+	}
+	return fmt.Errorf("player %s is not seated", playerID)
+}
+
+// EmptySeats returns the seats nobody is sitting in or holding, lowest first.
+func (t *Table) EmptySeats() []int {
+	seats := make([]int, 0)
+	for seat, player := range t.Seats {
+		if _, ok := t.reserved[seat]; player == nil && !ok {
+			seats = append(seats, seat)
 		}
 	}
+	return seats
 }
 
+// Reserve holds an empty seat so only the given player can sit in it.
+func (t *Table) Reserve(seat int, playerID string) error {
+	if seat < 0 || seat >= t.MaxPlayers {
+		return fmt.Errorf("seat %d does not exist: table has %d seats", seat, t.MaxPlayers)
+	}
+	if t.PlayerAt(seat) != nil {
+		return fmt.Errorf("seat %d is taken", seat)
+	}
+	if _, ok := t.reserved[seat]; ok {
+		return fmt.Errorf("seat %d is already reserved", seat)
+	}
+	t.reserved[seat] = playerID
+	return nil
+}
+
+// Release gives up the hold on a reserved seat.
+func (t *Table) Release(seat int) {
+	delete(t.reserved, seat)
+}
+
+// buyInLimits returns the smallest and largest buy-in allowed for a player,
+// taking a recent departure into account.
+func (t *Table) buyInLimits(playerID string) (int, int) {
+	minBuyIn, maxBuyIn := t.MinBuyIn, t.MaxBuyIn
+	if left, ok := t.departures[playerID]; ok && t.clock().Sub(left.at) < t.RatholeWindow {
+		if left.stack > minBuyIn {
+			minBuyIn = left.stack
+		}
+		if maxBuyIn > 0 && left.stack > maxBuyIn {
+			maxBuyIn = left.stack
+		}
+	}
+	return minBuyIn, maxBuyIn
+}
+
+// TopUp adds chips to a seated player's stack, up to the maximum buy-in. A
+// player with no chips left must rebuy at least the minimum buy-in. Chips
+// added while a hand is in progress are queued until it ends.
+func (t *Table) TopUp(playerID string, amount int) error {
+	player := t.findPlayer(playerID)
+	if player == nil {
+		return fmt.Errorf("player %s is not seated", playerID)
+	}
+	if amount <= 0 {
+		return fmt.Errorf("top-up must be positive, got %d", amount)
+	}
+	stack := player.Stack + player.PendingChips
+	if stack == 0 && amount < t.MinBuyIn {
+		return fmt.Errorf("rebuy of %d is below the minimum of %d", amount, t.MinBuyIn)
+	}
+	if t.MaxBuyIn > 0 && stack+amount > t.MaxBuyIn {
+		return fmt.Errorf("top-up of %d would take the stack above the maximum of %d", amount, t.MaxBuyIn)
+	}
+
+	if t.HandInProgress {
+		player.PendingChips += amount
+		log.Printf("Player %s will add %d chips after the hand.\n", player.Name, amount)
+		return nil
+	}
+	player.Stack += amount
+	log.Printf("Player %s added %d chips.\n", player.Name, amount)
+	return nil
+}
+
+// EndHand marks the current hand as over and adds any chips that were
+// queued during it to the players' stacks.
+func (t *Table) EndHand() {
+	t.HandInProgress = false
+	for _, player := range t.Players {
+		if player.PendingChips > 0 {
+			player.Stack += player.PendingChips
+			log.Printf("Player %s added %d chips.\n", player.Name, player.PendingChips)
+			player.PendingChips = 0
+		}
+	}
+}
+
+func (t *Table) RemovePlayer(playerID string) {
+	t.StandUp(playerID)
+}
+
+// PlayerAt returns the player in the given seat, or nil if it is empty.
+func (t *Table) PlayerAt(seat int) *player.Player {
+	if seat < 0 || seat >= t.MaxPlayers {
+		return nil
+	}
+	return t.Seats[seat]
+}
+
+// SitOutNextHand makes a player sit out from the next hand on.
+func (t *Table) SitOutNextHand(playerID string) error {
+	player := t.findPlayer(playerID)
+	if player == nil {
+		return fmt.Errorf("player %s is not seated", playerID)
+	}
+	player.SitOutNextHand = true
+	return nil
+}
+
+// SitOutNextBigBlind makes a player sit out when the big blind next reaches
+// them, so they play until then without posting it.
+func (t *Table) SitOutNextBigBlind(playerID string) error {
+	player := t.findPlayer(playerID)
+	if player == nil {
+		return fmt.Errorf("player %s is not seated", playerID)
+	}
+	player.SitOutNextBigBlind = true
+	return nil
+}
+
+// SitIn deals a player into hands again and cancels any pending sit-out.
+func (t *Table) SitIn(playerID string) error {
+	player := t.findPlayer(playerID)
+	if player == nil {
+		return fmt.Errorf("player %s is not seated", playerID)
+	}
+	player.SitOutNextHand = false
+	player.SitOutNextBigBlind = false
+	player.SitIn()
+	delete(t.orbitsSittingOut, playerID)
+	return nil
+}
+
+// RotateDealer places the button and blinds for the next hand using the dead
+// button rule: the big blind moves to the next player who is playing, the
+// small blind takes the previous big blind's seat and the button the previous
+// small blind's seat, even if those seats are now empty. Players sitting out
+// whom the big blind passes owe both blinds when they return. Heads-up the
+// button posts the small blind and is never dead.
 func (t *Table) RotateDealer() {
-	t.DealerPosition = (t.DealerPosition + 1) % len(t.Players)
-	log.Printf("Dealer button moved to player %s.\n", t.Players[t.DealerPosition].Name)
+	t.sitOutPending()
+	if len(t.playing()) < 2 {
+		t.dealtIn = nil
+		log.Println("Not enough players to move the button.")
+		return
+	}
+
+	if t.BigBlindSeat == -1 {
+		t.DealerPosition = t.nextPlayingSeat(t.DealerPosition)
+		t.SmallBlindSeat = t.nextPlayingSeat(t.DealerPosition)
+		if len(t.playing()) == 2 {
+			t.SmallBlindSeat = t.DealerPosition
+		}
+		t.BigBlindSeat = t.nextBigBlindSeat(t.SmallBlindSeat)
+		t.dealtIn = t.playing()
+		t.HandInProgress = true
+		log.Printf("Dealer button moved to seat %d.\n", t.DealerPosition)
+		return
+	}
+
+	bigBlindSeat := t.nextBigBlindSeat(t.BigBlindSeat)
+	leaving := make([]string, 0)
+	for seat := (t.BigBlindSeat + 1) % t.MaxPlayers; seat != bigBlindSeat; seat = (seat + 1) % t.MaxPlayers {
+		if player := t.PlayerAt(seat); player != nil && player.SittingOut {
+			player.MissedBigBlind = true
+			player.MissedSmallBlind = true
+			log.Printf("Player %s missed the blinds.\n", player.Name)
+
+			t.orbitsSittingOut[player.ID]++
+			if t.MaxOrbitsSittingOut > 0 && t.orbitsSittingOut[player.ID] >= t.MaxOrbitsSittingOut {
+				leaving = append(leaving, player.ID)
+			}
+		}
+	}
+
+	if len(t.playing()) == 2 {
+		t.DealerPosition = t.nextPlayingSeat(bigBlindSeat)
+		t.SmallBlindSeat = t.DealerPosition
+	} else {
+		t.DealerPosition = t.SmallBlindSeat
+		t.SmallBlindSeat = t.BigBlindSeat
+		if player := t.PlayerAt(t.SmallBlindSeat); player != nil && player.SittingOut {
+			player.MissedSmallBlind = true
+			log.Printf("Player %s missed the small blind.\n", player.Name)
+		}
+	}
+	t.BigBlindSeat = bigBlindSeat
+	t.dealtIn = t.playing()
+	t.HandInProgress = true
+
+	for _, playerID := range leaving {
+		log.Printf("Player %s sat out for %d orbits.\n", playerID, t.MaxOrbitsSittingOut)
+		t.StandUp(playerID)
+	}
+
+	if player := t.PlayerAt(t.DealerPosition); player != nil && !player.SittingOut {
+		log.Printf("Dealer button moved to player %s.\n", player.Name)
+	} else {
+		log.Printf("Dead button in seat %d.\n", t.DealerPosition)
+	}
 }
 
-// ActivePlayers returns a list of active players at the table.
-func (t *Table) ActivePlayers() []*player.Player {
-	activePlayers := make([]*player.Player, 0)
+// HoldButton starts a hand without blinds, such as a bomb pot, leaving the
+// button and blinds where they are so that the next hand's blinds fall on
+// the players due them.
+func (t *Table) HoldButton() {
+	t.sitOutPending()
+	if len(t.playing()) < 2 {
+		t.dealtIn = nil
+		log.Println("Not enough players to start a hand.")
+		return
+	}
+	t.dealtIn = t.playing()
+	t.HandInProgress = true
+	log.Println("Button and blinds stay put for a hand without blinds.")
+}
+
+// sitOutPending sits out the players who asked to from the next hand.
+func (t *Table) sitOutPending() {
+	for _, player := range t.Players {
+		if player.SitOutNextHand {
+			player.SitOutNextHand = false
+			player.SitOut()
+		}
+	}
+}
+
+// NextBigBlindSeat returns the seat of the player due to post the big blind
+// in the next hand, or -1 if fewer than two players are playing.
+func (t *Table) NextBigBlindSeat() int {
+	if len(t.playing()) < 2 {
+		return -1
+	}
+	if t.BigBlindSeat != -1 {
+		return t.nextPlayingSeat(t.BigBlindSeat)
+	}
+	smallBlindSeat := t.nextPlayingSeat(t.nextPlayingSeat(t.DealerPosition))
+	if len(t.playing()) == 2 {
+		smallBlindSeat = t.nextPlayingSeat(t.DealerPosition)
+	}
+	return t.nextPlayingSeat(smallBlindSeat)
+}
+
+// nextBigBlindSeat returns the seat of the next big blind after seat. Players
+// who asked to sit out at their next big blind do so now and are skipped.
+func (t *Table) nextBigBlindSeat(seat int) int {
+	next := t.nextPlayingSeat(seat)
+	for next != -1 && t.Seats[next].SitOutNextBigBlind {
+		t.Seats[next].SitOutNextBigBlind = false
+		t.Seats[next].SitOut()
+		next = t.nextPlayingSeat(seat)
+	}
+	return next
+}
+
+// nextPlayingSeat returns the first seat after seat whose player is playing.
+func (t *Table) nextPlayingSeat(seat int) int {
+	for i := 1; i <= t.MaxPlayers; i++ {
+		next := (seat + i) % t.MaxPlayers
+		if player := t.PlayerAt(next); player != nil && isPlaying(player) {
+			return next
+		}
+	}
+	return -1
+}
+
+// playing returns the players who will be dealt into the next hand.
+func (t *Table) playing() []*player.Player {
+	playing := make([]*player.Player, 0)
 	for _, player := range t.Players {
-		if player.Active {
-			activePlayers = append(activePlayers, player)
+		if isPlaying(player) {
+			playing = append(playing, player)
 		}
 	}
-	return activePlayers
+	return playing
+}
+
+func isPlaying(p *player.Player) bool {
+	return !p.SittingOut && p.Stack > 0
+}
+
+// updatePlayers rebuilds the list of seated players from the seats.
+func (t *Table) updatePlayers() {
+	t.Players = make([]*player.Player, 0)
+	for _, player := range t.Seats {
+		if player != nil {
+			t.Players = append(t.Players, player)
+		}
+	}
+}
+
+func (t *Table) findPlayer(playerID string) *player.Player {
+	for _, player := range t.Players {
+		if player.ID == playerID {
+			return player
+		}
+	}
+	return nil
+}
+
+// ActivePlayers returns the players dealt into the current hand, including
+// any who have folded since.
+func (t *Table) ActivePlayers() []*player.Player {
+	return append([]*player.Player{}, t.dealtIn...)
 }
@@ -2,6 +2,7 @@ package table
 
 import (
 	"testing"
+	"time"
 
 	"github.com/prfc0/aksha/internal/player"
 )
@@ -60,13 +61,334 @@ func TestActivePlayers(t *testing.T) {
 	player1 := player.NewPlayer("1", "Alice", 1000)
 	player2 := player.NewPlayer("2", "Bob", 1000)
 
+	player3 := player.NewPlayer("3", "Charlie", 1000)
+
 	table.AddPlayer(player1)
 	table.AddPlayer(player2)
+	table.AddPlayer(player3)
 
+	player3.SitOut()
+	table.RotateDealer()
 	player2.Fold()
 
 	activePlayers := table.ActivePlayers()
-	if len(activePlayers) != 1 || activePlayers[0].ID != "1" {
-		t.Error("Failed to get active players")
+	if len(activePlayers) != 2 || activePlayers[0].ID != "1" || activePlayers[1].ID != "2" {
+		t.Error("Failed to get the players dealt into the hand")
+	}
+}
+
+func newFourSeatTable() *Table {
+	table := NewTable(6)
+	table.AddPlayer(player.NewPlayer("1", "Alice", 1000))
+	table.AddPlayer(player.NewPlayer("2", "Bob", 1000))
+	table.AddPlayer(player.NewPlayer("3", "Charlie", 1000))
+	table.AddPlayer(player.NewPlayer("4", "Dave", 1000))
+	return table
+}
+
+func TestFixedSeats(t *testing.T) {
+	table := newFourSeatTable()
+	table.RemovePlayer("2")
+	table.AddPlayer(player.NewPlayer("5", "Eve", 1000))
+
+	if table.PlayerAt(1).Name != "Eve" || table.PlayerAt(3).Name != "Dave" {
+		t.Error("Expected seats to stay fixed when players leave and join")
+	}
+	if table.Players[1].Name != "Eve" {
+		t.Error("Expected players to be kept in seat order")
+	}
+}
+
+func TestDeadButton(t *testing.T) {
+	table := newFourSeatTable()
+	table.RotateDealer()
+	if table.DealerPosition != 1 || table.SmallBlindSeat != 2 || table.BigBlindSeat != 3 {
+		t.Fatalf("Expected button 1, blinds 2 and 3, got %d, %d and %d", table.DealerPosition, table.SmallBlindSeat, table.BigBlindSeat)
+	}
+
+	// The small blind leaves, so the button is dead next hand
+	table.RemovePlayer("3")
+	table.RotateDealer()
+	if table.DealerPosition != 2 || table.SmallBlindSeat != 3 || table.BigBlindSeat != 0 {
+		t.Errorf("Expected dead button in seat 2, blinds 3 and 0, got %d, %d and %d", table.DealerPosition, table.SmallBlindSeat, table.BigBlindSeat)
+	}
+}
+
+func TestDeadSmallBlind(t *testing.T) {
+	table := newFourSeatTable()
+	table.RotateDealer()
+
+	// The big blind leaves, so nobody posts the small blind next hand
+	table.RemovePlayer("4")
+	table.RotateDealer()
+	if table.SmallBlindSeat != 3 || table.PlayerAt(table.SmallBlindSeat) != nil || table.BigBlindSeat != 0 {
+		t.Errorf("Expected a dead small blind in seat 3 and the big blind in seat 0, got %d and %d", table.SmallBlindSeat, table.BigBlindSeat)
+	}
+}
+
+func TestMissedBlinds(t *testing.T) {
+	table := newFourSeatTable()
+	table.AddPlayer(player.NewPlayer("5", "Eve", 1000))
+	table.RotateDealer()
+	table.RotateDealer()
+
+	alice := table.PlayerAt(0)
+	alice.SitOut()
+	table.RotateDealer()
+	if table.BigBlindSeat != 1 {
+		t.Errorf("Expected the big blind to skip a player sitting out, got seat %d", table.BigBlindSeat)
+	}
+	if !alice.MissedBigBlind || !alice.MissedSmallBlind {
+		t.Error("Expected a player sitting out to miss both blinds when the big blind passes")
+	}
+
+	bob := table.PlayerAt(1)
+	bob.SitOut()
+	table.RotateDealer()
+	if !bob.MissedSmallBlind || bob.MissedBigBlind {
+		t.Error("Expected the previous big blind to miss only the small blind")
+	}
+}
+
+func TestRotateDealerHeadsUp(t *testing.T) {
+	table := newFourSeatTable()
+	table.RotateDealer()
+
+	table.RemovePlayer("1")
+	table.RemovePlayer("2")
+	table.RotateDealer()
+	if table.BigBlindSeat != 2 || table.DealerPosition != 3 || table.SmallBlindSeat != 3 {
+		t.Errorf("Expected the button to post the small blind heads-up, got button %d, blinds %d and %d", table.DealerPosition, table.SmallBlindSeat, table.BigBlindSeat)
+	}
+}
+
+func TestSitDown(t *testing.T) {
+	table := NewTable(6)
+	alice := player.NewPlayer("1", "Alice", 0)
+
+	if err := table.SitDown(3, alice, 500); err != nil {
+		t.Fatalf("Unexpected error sitting down: %v", err)
+	}
+	if table.Seats[3] != alice || alice.Seat != 3 || alice.Stack != 500 {
+		t.Error("Expected Alice in seat 3 with 500 chips")
+	}
+	if table.Seats[0] != nil || len(table.Players) != 1 {
+		t.Error("Expected the other seats to stay empty")
+	}
+
+	tests := []struct {
+		seat   int
+		player *player.Player
+		buyIn  int
+	}{
+		{3, player.NewPlayer("2", "Bob", 0), 500}, // Seat taken
+		{6, player.NewPlayer("2", "Bob", 0), 500}, // No such seat
+		{1, alice, 500},                         // Already seated
+		{1, player.NewPlayer("2", "Bob", 0), 0}, // No chips
+	}
+	for _, test := range tests {
+		if err := table.SitDown(test.seat, test.player, test.buyIn); err == nil {
+			t.Errorf("Expected an error seating %s in seat %d with %d chips", test.player.Name, test.seat, test.buyIn)
+		}
+	}
+
+	if err := table.StandUp("1"); err != nil || table.Seats[3] != nil || len(table.Players) != 0 {
+		t.Error("Failed to stand up")
+	}
+	if err := table.StandUp("1"); err == nil {
+		t.Error("Expected an error standing up a player who is not seated")
+	}
+}
+
+func TestSitOutNextHand(t *testing.T) {
+	table := newFourSeatTable()
+	table.RotateDealer()
+
+	table.SitOutNextHand("1")
+	if table.PlayerAt(0).SittingOut {
+		t.Error("Expected the player to keep playing the current hand")
+	}
+	table.RotateDealer()
+	if !table.PlayerAt(0).SittingOut || len(table.ActivePlayers()) != 3 {
+		t.Error("Expected the player to sit out from the next hand")
+	}
+
+	table.SitIn("1")
+	table.RotateDealer()
+	if len(table.ActivePlayers()) != 4 {
+		t.Error("Expected the player to be dealt in after sitting in")
+	}
+}
+
+func TestSitOutNextBigBlind(t *testing.T) {
+	table := newFourSeatTable()
+	table.AddPlayer(player.NewPlayer("5", "Eve", 1000))
+	table.RotateDealer()
+
+	// Eve in seat 4 would be the next big blind
+	table.SitOutNextBigBlind("5")
+	table.RotateDealer()
+	eve := table.PlayerAt(4)
+	if !eve.SittingOut || table.BigBlindSeat != 0 {
+		t.Errorf("Expected Eve to sit out instead of posting the big blind, big blind in seat %d", table.BigBlindSeat)
+	}
+	if !eve.MissedBigBlind {
+		t.Error("Expected Eve to owe the big blind on returning")
+	}
+}
+
+func TestRemovedAfterOrbitsSittingOut(t *testing.T) {
+	table := newFourSeatTable()
+	table.MaxOrbitsSittingOut = 2
+	table.RotateDealer()
+	table.PlayerAt(0).SitOut()
+
+	// The big blind passes the player on the first rotation and every third one after
+	table.RotateDealer()
+	table.RotateDealer()
+	table.RotateDealer()
+	if table.PlayerAt(0) == nil {
+		t.Fatal("Expected the player to keep the seat after one orbit")
+	}
+	table.RotateDealer()
+	if table.PlayerAt(0) != nil {
+		t.Error("Expected the player to lose the seat after two orbits sitting out")
+	}
+}
+
+func TestBuyInLimits(t *testing.T) {
+	table := NewTable(6)
+	table.MinBuyIn = 400
+	table.MaxBuyIn = 1000
+
+	tests := []struct {
+		buyIn       int
+		expectError bool
+	}{
+		{300, true},   // Below the minimum
+		{1200, true},  // Above the maximum
+		{400, false},  // The minimum
+		{1000, false}, // The maximum
+	}
+	for i, test := range tests {
+		err := table.SitDown(i, player.NewPlayer(string(rune('a'+i)), "Player", 0), test.buyIn)
+		if (err != nil) != test.expectError {
+			t.Errorf("Expected error=%v for buy-in %d, got %v", test.expectError, test.buyIn, err)
+		}
+	}
+}
+
+func TestTopUp(t *testing.T) {
+	table := NewTable(6)
+	table.MinBuyIn = 400
+	table.MaxBuyIn = 1000
+	alice := player.NewPlayer("1", "Alice", 0)
+	table.SitDown(0, alice, 500)
+
+	if err := table.TopUp("1", 600); err == nil {
+		t.Error("Expected an error topping up above the maximum buy-in")
+	}
+	if err := table.TopUp("1", 500); err != nil || alice.Stack != 1000 {
+		t.Errorf("Expected top-up to 1000 between hands, got %d: %v", alice.Stack, err)
+	}
+
+	alice.Stack = 0
+	if err := table.TopUp("1", 200); err == nil {
+		t.Error("Expected a rebuy below the minimum buy-in to fail")
+	}
+	if err := table.TopUp("1", 400); err != nil || alice.Stack != 400 {
+		t.Errorf("Expected a rebuy of 400, got %d: %v", alice.Stack, err)
+	}
+}
+
+func TestTopUpQueuedDuringHand(t *testing.T) {
+	table := newFourSeatTable()
+	table.RotateDealer()
+	alice := table.PlayerAt(0)
+
+	if err := table.TopUp("1", 200); err != nil {
+		t.Fatalf("Unexpected error topping up: %v", err)
+	}
+	if alice.Stack != 1000 || alice.PendingChips != 200 {
+		t.Errorf("Expected chips added mid-hand to be queued, got stack %d, pending %d", alice.Stack, alice.PendingChips)
+	}
+
+	table.EndHand()
+	if alice.Stack != 1200 || alice.PendingChips != 0 {
+		t.Errorf("Expected queued chips to be added when the hand ends, got stack %d", alice.Stack)
+	}
+}
+
+func TestRatholeGuard(t *testing.T) {
+	now := time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)
+	table := NewTable(6)
+	table.MinBuyIn = 400
+	table.MaxBuyIn = 1000
+	table.RatholeWindow = time.Hour
+	table.clock = func() time.Time { return now }
+
+	alice := player.NewPlayer("1", "Alice", 0)
+	table.SitDown(0, alice, 1000)
+	alice.Stack = 2500
+	table.StandUp("1")
+
+	now = now.Add(30 * time.Minute)
+	if err := table.SitDown(0, alice, 1000); err == nil {
+		t.Error("Expected a player rejoining within the window to bring back the previous stack")
+	}
+	if err := table.SitDown(0, alice, 2500); err != nil {
+		t.Errorf("Expected the previous stack to be allowed above the maximum: %v", err)
+	}
+
+	alice.Stack = 2500
+	table.StandUp("1")
+	now = now.Add(2 * time.Hour)
+	if err := table.SitDown(0, alice, 1000); err != nil {
+		t.Errorf("Expected the usual limits once the window has passed: %v", err)
+	}
+}
+
+func TestReserveSeat(t *testing.T) {
+	table := NewTable(2)
+	if err := table.Reserve(0, "2"); err != nil {
+		t.Fatalf("Unexpected error reserving a seat: %v", err)
+	}
+	if seats := table.EmptySeats(); len(seats) != 1 || seats[0] != 1 {
+		t.Errorf("Expected only seat 1 to be empty, got %v", seats)
+	}
+
+	alice := player.NewPlayer("1", "Alice", 1000)
+	if err := table.SitDown(0, alice, 1000); err == nil {
+		t.Error("Expected an error sitting in a seat reserved for someone else")
+	}
+	if err := table.AddPlayer(alice); err != nil || alice.Seat != 1 {
+		t.Error("Expected AddPlayer to skip the reserved seat")
+	}
+
+	bob := player.NewPlayer("2", "Bob", 1000)
+	if err := table.SitDown(0, bob, 1000); err != nil {
+		t.Errorf("Expected the player holding the seat to sit in it: %v", err)
+	}
+}
+
+func TestSeatOpened(t *testing.T) {
+	table := newFourSeatTable()
+	opened := -1
+	table.SeatOpened = func(seat int) { opened = seat }
+
+	table.StandUp("3")
+	if opened != 2 {
+		t.Errorf("Expected to be told seat 2 opened, got %d", opened)
+	}
+}
+
+func TestNextBigBlindSeat(t *testing.T) {
+	table := newFourSeatTable()
+	if seat := table.NextBigBlindSeat(); seat != 3 {
+		t.Errorf("Expected seat 3 to post the first big blind, got %d", seat)
+	}
+	table.RotateDealer()
+	if seat := table.NextBigBlindSeat(); seat != 0 {
+		t.Errorf("Expected seat 0 to post the next big blind, got %d", seat)
 	}
 }
@@ -0,0 +1,402 @@
+package tournament
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/prfc0/aksha/internal/game"
+	"github.com/prfc0/aksha/internal/player"
+	"github.com/prfc0/aksha/internal/schedule"
+	"github.com/prfc0/aksha/internal/table"
+)
+
+// Result is the finishing position and prize of a player who is out of the
+// tournament, or of the winner.
+type Result struct {
+	Player   *player.Player
+	Position int
+	Prize    int
+}
+
+// Tournament runs a sit-and-go or multi-table tournament: it draws seats,
+// raises the blinds, records eliminations, breaks and balances tables as
+// players bust, and plays hand-for-hand on the bubble.
+type Tournament struct {
+	BuyIn         int                // Chips each player pays into the prize pool
+	StartingStack int                // Tournament chips each player starts with
+	TableSize     int                // Number of seats at each table
+	Schedule      *schedule.Schedule // Blind levels shared by every table; levels counted in hands count the hands at all tables
+	Payouts       []int              // Percentage of the prize pool for each paid place, first place first
+	Players       []*player.Player   // Registered players
+	Tables        []*table.Table     // Tables still in play
+	Games         []*game.Game       // Game played at each table
+	Results       []*Result          // Finishing positions, in the order they were decided
+	HandForHand   bool               // Whether every table plays one hand at a time
+
+	started        bool
+	handStacks     map[*player.Player]int // Stacks at the start of each player's current hand
+	handPlayed     map[*table.Table]bool  // Tables that have played the current hand-for-hand hand
+	handForHandOut [][]*player.Player     // Players out during the current hand-for-hand hand, table by table
+	rand           *rand.Rand
+}
+
+func NewTournament(buyIn, startingStack, tableSize int, blinds *schedule.Schedule, payouts []int) *Tournament {
+	return &Tournament{
+		BuyIn:         buyIn,
+		StartingStack: startingStack,
+		TableSize:     tableSize,
+		Schedule:      blinds,
+		Payouts:       payouts,
+		Players:       make([]*player.Player, 0),
+		Tables:        make([]*table.Table, 0),
+		Games:         make([]*game.Game, 0),
+		Results:       make([]*Result, 0),
+		handStacks:    make(map[*player.Player]int),
+		handPlayed:    make(map[*table.Table]bool),
+		rand:          rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Register enters a player into the tournament before it starts.
+func (t *Tournament) Register(p *player.Player) error {
+	if t.started {
+		return fmt.Errorf("registration is closed")
+	}
+	for _, registered := range t.Players {
+		if registered.ID == p.ID {
+			return fmt.Errorf("player %s is already registered", p.Name)
+		}
+	}
+	t.Players = append(t.Players, p)
+	log.Printf("Player %s registered.\n", p.Name)
+	return nil
+}
+
+// Start closes registration, gives every player the starting stack and
+// draws seats at as few tables as will hold them, spread evenly.
+func (t *Tournament) Start() error {
+	if t.started {
+		return fmt.Errorf("tournament has already started")
+	}
+	if len(t.Players) < 2 {
+		return fmt.Errorf("at least two players are needed, got %d", len(t.Players))
+	}
+	if err := t.Schedule.Validate(); err != nil {
+		return err
+	}
+	t.started = true
+
+	numTables := (len(t.Players) + t.TableSize - 1) / t.TableSize
+	drawn := make([][]*player.Player, numTables)
+	for i, index := range t.rand.Perm(len(t.Players)) {
+		drawn[i%numTables] = append(drawn[i%numTables], t.Players[index])
+	}
+
+	for _, players := range drawn {
+		tbl := table.NewTable(t.TableSize)
+		seats := t.rand.Perm(t.TableSize)
+		for i, p := range players {
+			if err := tbl.SitDown(seats[i], p, t.StartingStack); err != nil {
+				return err
+			}
+		}
+		tbl.DealerPosition = players[t.rand.Intn(len(players))].Seat
+
+		g := game.NewGame(tbl.Players, 0, 0)
+		g.Table = tbl
+		t.Tables = append(t.Tables, tbl)
+		t.Games = append(t.Games, g)
+	}
+
+	t.Schedule.Start()
+	log.Printf("Tournament started with %d players at %d tables.\n", len(t.Players), numTables)
+	return nil
+}
+
+// PrizePool returns the total prize money.
+func (t *Tournament) PrizePool() int {
+	return t.BuyIn * len(t.Players)
+}
+
+// Prize returns the prize for finishing in the given position. First place
+// also receives whatever rounding leaves over.
+func (t *Tournament) Prize(position int) int {
+	if position < 1 || position > len(t.Payouts) {
+		return 0
+	}
+	prize := t.PrizePool() * t.Payouts[position-1] / 100
+	if position == 1 {
+		paid := 0
+		for _, percentage := range t.Payouts {
+			paid += t.PrizePool() * percentage / 100
+		}
+		prize += t.PrizePool()*sumOf(t.Payouts)/100 - paid
+	}
+	return prize
+}
+
+// Remaining returns the players still in the tournament.
+func (t *Tournament) Remaining() []*player.Player {
+	remaining := make([]*player.Player, 0)
+	for _, p := range t.Players {
+		if !t.isOut(p) {
+			remaining = append(remaining, p)
+		}
+	}
+	return remaining
+}
+
+// Finished reports whether the tournament has a winner.
+func (t *Tournament) Finished() bool {
+	return t.started && len(t.Remaining()) <= 1
+}
+
+// GameAt returns the game played at a table.
+func (t *Tournament) GameAt(tbl *table.Table) *game.Game {
+	for i, tournamentTable := range t.Tables {
+		if tournamentTable == tbl {
+			return t.Games[i]
+		}
+	}
+	return nil
+}
+
+// StartHand starts the next hand at a table with the current blinds. No
+// hands start during a break, and during hand-for-hand a table that has
+// played its hand waits for the others.
+func (t *Tournament) StartHand(tbl *table.Table) error {
+	g := t.GameAt(tbl)
+	if g == nil {
+		return fmt.Errorf("table is not in play")
+	}
+	if t.Finished() {
+		return fmt.Errorf("tournament is over")
+	}
+	if t.HandForHand && t.handPlayed[tbl] {
+		return fmt.Errorf("waiting for the other tables to finish the hand")
+	}
+
+	t.Schedule.Advance()
+	if t.Schedule.OnBreak() {
+		return fmt.Errorf("tournament is on a break for another %v", t.Schedule.TimeRemaining())
+	}
+	level := t.Schedule.Blinds()
+	g.SmallBlind = level.SmallBlind
+	g.BigBlind = level.BigBlind
+	g.Ante = level.Ante
+	g.BigBlindAnte = level.BigBlindAnte
+
+	for _, p := range tbl.Players {
+		t.handStacks[p] = p.Stack
+	}
+	return g.StartHand()
+}
+
+// EndHand ends the hand at a table, records the players it knocked out and
+// then breaks or balances tables as needed.
+func (t *Tournament) EndHand(tbl *table.Table) {
+	g := t.GameAt(tbl)
+	if g == nil {
+		return
+	}
+	g.EndHand()
+	t.Schedule.HandPlayed()
+
+	out := make([]*player.Player, 0)
+	for _, p := range append([]*player.Player{}, tbl.Players...) {
+		if p.Stack == 0 {
+			out = append(out, p)
+			tbl.StandUp(p.ID)
+		}
+	}
+
+	if t.HandForHand {
+		if len(out) > 0 {
+			t.handForHandOut = append(t.handForHandOut, out)
+		}
+		t.handPlayed[tbl] = true
+		t.finishHandForHand()
+	} else {
+		t.eliminate(out)
+	}
+
+	t.breakOrBalance(tbl)
+	t.updateHandForHand()
+
+	if remaining := t.Remaining(); len(remaining) == 1 {
+		winner := remaining[0]
+		t.Results = append(t.Results, &Result{Player: winner, Position: 1, Prize: t.Prize(1)})
+		log.Printf("Player %s wins the tournament.\n", winner.Name)
+	}
+}
+
+// eliminate records the finishing positions of players knocked out at the
+// same time, given table by table. At a table the player who started the
+// hand with more chips finishes higher, and players who started with the
+// same stack tie. Players going out at different tables in the same
+// hand-for-hand hand tie with those ranked the same at their own tables:
+// the shortest stacks at each table tie for the lowest places, the next
+// shortest for the places above, and so on. Tied players share the prizes
+// for their places.
+func (t *Tournament) eliminate(tables ...[]*player.Player) {
+	tiers := make([][]*player.Player, 0)
+	count := 0
+	for _, out := range tables {
+		out = append([]*player.Player{}, out...)
+		sort.SliceStable(out, func(i, j int) bool {
+			return t.handStacks[out[i]] < t.handStacks[out[j]]
+		})
+		tier := -1
+		for i, p := range out {
+			if i == 0 || t.handStacks[p] != t.handStacks[out[i-1]] {
+				tier++
+			}
+			if tier == len(tiers) {
+				tiers = append(tiers, make([]*player.Player, 0))
+			}
+			tiers[tier] = append(tiers[tier], p)
+		}
+		count += len(out)
+	}
+
+	position := len(t.Players) - len(t.Results) - count + 1
+	for i := len(tiers) - 1; i >= 0; i-- {
+		prize := 0
+		for place := position; place < position+len(tiers[i]); place++ {
+			prize += t.Prize(place)
+		}
+		for _, p := range tiers[i] {
+			t.Results = append(t.Results, &Result{Player: p, Position: position, Prize: prize / len(tiers[i])})
+			log.Printf("Player %s finished in position %d.\n", p.Name, position)
+		}
+		position += len(tiers[i])
+	}
+}
+
+// finishHandForHand records the players knocked out once every table has
+// played the current hand-for-hand hand.
+func (t *Tournament) finishHandForHand() {
+	for _, tbl := range t.Tables {
+		if !t.handPlayed[tbl] {
+			return
+		}
+	}
+	t.eliminate(t.handForHandOut...)
+	t.handForHandOut = nil
+	t.handPlayed = make(map[*table.Table]bool)
+}
+
+// handForHandOutCount returns the number of players out during the current
+// hand-for-hand hand.
+func (t *Tournament) handForHandOutCount() int {
+	count := 0
+	for _, out := range t.handForHandOut {
+		count += len(out)
+	}
+	return count
+}
+
+// updateHandForHand plays hand-for-hand while one more player remains than
+// there are paid places and more than one table is in play.
+func (t *Tournament) updateHandForHand() {
+	bubble := len(t.Remaining())-t.handForHandOutCount() == len(t.Payouts)+1 && len(t.Tables) > 1
+	if bubble && !t.HandForHand {
+		log.Println("Hand-for-hand play begins.")
+	}
+	if !bubble && t.HandForHand {
+		t.finishHandForHand()
+		if len(t.handForHandOut) > 0 {
+			return
+		}
+		log.Println("Hand-for-hand play ends.")
+	}
+	t.HandForHand = bubble
+}
+
+// breakOrBalance moves players away from a table that has just finished a
+// hand: every player if the table is the next to break, or enough players to
+// leave no table with two or more players more than another.
+func (t *Tournament) breakOrBalance(tbl *table.Table) {
+	if len(t.Tables) < 2 {
+		return
+	}
+
+	remaining := len(t.Remaining()) - t.handForHandOutCount()
+	tablesNeeded := (remaining + t.TableSize - 1) / t.TableSize
+	if tablesNeeded < len(t.Tables) && tbl == t.Tables[len(t.Tables)-1] {
+		log.Println("Breaking a table.")
+		for len(tbl.Players) > 0 {
+			if err := t.movePlayer(tbl, tbl.Players[0]); err != nil {
+				log.Printf("Table not broken: %v\n", err)
+				return
+			}
+		}
+		t.Tables = t.Tables[:len(t.Tables)-1]
+		t.Games = t.Games[:len(t.Games)-1]
+		delete(t.handPlayed, tbl)
+		if t.HandForHand {
+			t.finishHandForHand()
+		}
+		return
+	}
+
+	for len(tbl.Players)-len(t.smallestTable(tbl).Players) > 1 {
+		seat := tbl.NextBigBlindSeat()
+		if seat == -1 {
+			seat = tbl.Players[0].Seat
+		}
+		if err := t.movePlayer(tbl, tbl.PlayerAt(seat)); err != nil {
+			log.Printf("Tables not balanced: %v\n", err)
+			return
+		}
+	}
+}
+
+// movePlayer moves a player from one table to a random empty seat at the
+// table with the fewest players. The player is seated at the new table
+// before leaving the old one, so a move that fails leaves them where they
+// were.
+func (t *Tournament) movePlayer(from *table.Table, p *player.Player) error {
+	to := t.smallestTable(from)
+	seats := to.EmptySeats()
+	if len(seats) == 0 {
+		return fmt.Errorf("cannot move player %s: no empty seat at the smallest table", p.Name)
+	}
+	if err := to.SitDown(seats[t.rand.Intn(len(seats))], p, p.Stack); err != nil {
+		return fmt.Errorf("cannot move player %s: %v", p.Name, err)
+	}
+	from.StandUp(p.ID)
+	log.Printf("Player %s moved to seat %d at another table.\n", p.Name, p.Seat)
+	return nil
+}
+
+// smallestTable returns the table other than except with the fewest players.
+func (t *Tournament) smallestTable(except *table.Table) *table.Table {
+	var smallest *table.Table
+	for _, tbl := range t.Tables {
+		if tbl != except && (smallest == nil || len(tbl.Players) < len(smallest.Players)) {
+			smallest = tbl
+		}
+	}
+	return smallest
+}
+
+func (t *Tournament) isOut(p *player.Player) bool {
+	for _, result := range t.Results {
+		if result.Player == p {
+			return true
+		}
+	}
+	return false
+}
+
+func sumOf(values []int) int {
+	sum := 0
+	for _, value := range values {
+		sum += value
+	}
+	return sum
+}
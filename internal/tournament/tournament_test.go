@@ -0,0 +1,299 @@
+package tournament
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/prfc0/aksha/internal/player"
+	"github.com/prfc0/aksha/internal/schedule"
+	"github.com/prfc0/aksha/internal/table"
+)
+
+func newTestTournament(numPlayers, tableSize int, payouts []int) *Tournament {
+	blinds := schedule.NewSchedule([]schedule.Level{
+		{SmallBlind: 10, BigBlind: 20, Duration: 10 * time.Minute},
+		{Break: true, Duration: 5 * time.Minute},
+		{SmallBlind: 20, BigBlind: 40, Ante: 5, Duration: 10 * time.Minute},
+	})
+	tournament := NewTournament(100, 1000, tableSize, blinds, payouts)
+	tournament.rand = rand.New(rand.NewSource(1))
+	for i := 0; i < numPlayers; i++ {
+		tournament.Register(player.NewPlayer(fmt.Sprintf("%d", i), fmt.Sprintf("Player%d", i), 0))
+	}
+	return tournament
+}
+
+// bust plays a hand at a table in which the given players lose all their chips.
+func bust(t *testing.T, tournament *Tournament, tbl *table.Table, players ...*player.Player) {
+	if err := tournament.StartHand(tbl); err != nil {
+		t.Fatalf("Unexpected error starting hand: %v", err)
+	}
+	for _, p := range players {
+		p.Fold()
+		p.Stack = 0
+	}
+	tournament.EndHand(tbl)
+}
+
+func tableSizes(tournament *Tournament) []int {
+	sizes := make([]int, 0)
+	for _, tbl := range tournament.Tables {
+		sizes = append(sizes, len(tbl.Players))
+	}
+	return sizes
+}
+
+func TestRegisterAndSeatDraw(t *testing.T) {
+	tournament := newTestTournament(10, 6, []int{100})
+	if err := tournament.Register(tournament.Players[0]); err == nil {
+		t.Errorf("Expected error registering the same player twice")
+	}
+	if err := tournament.Start(); err != nil {
+		t.Fatalf("Unexpected error starting tournament: %v", err)
+	}
+	if err := tournament.Register(player.NewPlayer("10", "Late", 0)); err == nil {
+		t.Errorf("Expected error registering after the start")
+	}
+
+	if sizes := tableSizes(tournament); len(sizes) != 2 || sizes[0] != 5 || sizes[1] != 5 {
+		t.Errorf("Expected two tables of 5 players, got %v", sizes)
+	}
+	for _, p := range tournament.Players {
+		if p.Stack != 1000 {
+			t.Errorf("Expected %s to start with 1000 chips, got %d", p.Name, p.Stack)
+		}
+	}
+	if tournament.PrizePool() != 1000 {
+		t.Errorf("Expected prize pool of 1000, got %d", tournament.PrizePool())
+	}
+}
+
+func TestBlindLevels(t *testing.T) {
+	tournament := newTestTournament(2, 6, []int{100})
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tournament.Schedule.Clock = func() time.Time { return now }
+	tournament.Start()
+	tbl := tournament.Tables[0]
+	g := tournament.GameAt(tbl)
+
+	tournament.StartHand(tbl)
+	if g.SmallBlind != 10 || g.BigBlind != 20 || g.Ante != 0 {
+		t.Errorf("Expected blinds 10/20 with no ante, got %d/%d ante %d", g.SmallBlind, g.BigBlind, g.Ante)
+	}
+	tournament.EndHand(tbl)
+
+	now = now.Add(12 * time.Minute)
+	if err := tournament.StartHand(tbl); err == nil {
+		t.Errorf("Expected no hand to start during the break")
+	}
+
+	now = now.Add(3 * time.Minute)
+	if err := tournament.StartHand(tbl); err != nil {
+		t.Fatalf("Unexpected error after the break: %v", err)
+	}
+	if g.SmallBlind != 20 || g.BigBlind != 40 || g.Ante != 5 {
+		t.Errorf("Expected blinds 20/40 with ante 5, got %d/%d ante %d", g.SmallBlind, g.BigBlind, g.Ante)
+	}
+}
+
+func TestEliminationsAndPayouts(t *testing.T) {
+	tournament := newTestTournament(4, 6, []int{70, 30})
+	tournament.Start()
+	tbl := tournament.Tables[0]
+	players := tbl.Players
+
+	if err := tournament.StartHand(tbl); err != nil {
+		t.Fatalf("Unexpected error starting hand: %v", err)
+	}
+	// Both lose everything; the one who started with more finishes higher.
+	players[0].Stack, players[1].Stack = 0, 0
+	tournament.handStacks[players[0]] = 500
+	tournament.handStacks[players[1]] = 800
+	players[0].Fold()
+	players[1].Fold()
+	tournament.EndHand(tbl)
+
+	if len(tournament.Results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(tournament.Results))
+	}
+	if tournament.Results[0].Player != players[1] || tournament.Results[0].Position != 3 {
+		t.Errorf("Expected %s to finish third, got %s in %d", players[1].Name, tournament.Results[0].Player.Name, tournament.Results[0].Position)
+	}
+	if tournament.Results[1].Player != players[0] || tournament.Results[1].Position != 4 {
+		t.Errorf("Expected %s to finish fourth, got %s in %d", players[0].Name, tournament.Results[1].Player.Name, tournament.Results[1].Position)
+	}
+	if len(tbl.Players) != 2 {
+		t.Errorf("Expected busted players to leave the table, got %d players", len(tbl.Players))
+	}
+
+	remaining := tbl.Players
+	bust(t, tournament, tbl, remaining[0])
+	if !tournament.Finished() {
+		t.Fatalf("Expected the tournament to be finished")
+	}
+	second, first := tournament.Results[2], tournament.Results[3]
+	if second.Player != remaining[0] || second.Position != 2 || second.Prize != 120 {
+		t.Errorf("Expected %s second for 120, got %s in %d for %d", remaining[0].Name, second.Player.Name, second.Position, second.Prize)
+	}
+	if first.Player != remaining[1] || first.Position != 1 || first.Prize != 280 {
+		t.Errorf("Expected %s first for 280, got %s in %d for %d", remaining[1].Name, first.Player.Name, first.Position, first.Prize)
+	}
+	if err := tournament.StartHand(tbl); err == nil {
+		t.Errorf("Expected error starting a hand after the tournament is over")
+	}
+}
+
+func TestTableBalancing(t *testing.T) {
+	tournament := newTestTournament(10, 6, []int{100})
+	tournament.Start()
+	first, second := tournament.Tables[0], tournament.Tables[1]
+
+	bust(t, tournament, first, first.Players[0], first.Players[1])
+	if sizes := tableSizes(tournament); sizes[0] != 3 || sizes[1] != 5 {
+		t.Fatalf("Expected tables of 3 and 5, got %v", sizes)
+	}
+
+	tournament.StartHand(second)
+	moved := second.PlayerAt(second.NextBigBlindSeat())
+	tournament.EndHand(second)
+	if sizes := tableSizes(tournament); sizes[0] != 4 || sizes[1] != 4 {
+		t.Errorf("Expected tables of 4 and 4, got %v", sizes)
+	}
+	found := false
+	for _, p := range first.Players {
+		found = found || p == moved
+	}
+	if !found {
+		t.Errorf("Expected %s, due the big blind, to move tables", moved.Name)
+	}
+}
+
+func TestTableBreaking(t *testing.T) {
+	tournament := newTestTournament(6, 4, []int{100})
+	tournament.Start()
+	first, second := tournament.Tables[0], tournament.Tables[1]
+
+	bust(t, tournament, first, first.Players[0], first.Players[1])
+	if len(tournament.Tables) != 2 {
+		t.Fatalf("Expected the first table to stay open")
+	}
+	bust(t, tournament, second)
+	if len(tournament.Tables) != 1 || tournament.Tables[0] != first {
+		t.Fatalf("Expected the last table to break, got %d tables", len(tournament.Tables))
+	}
+	if len(first.Players) != 4 || len(second.Players) != 0 {
+		t.Errorf("Expected all 4 players at the first table, got %d and %d", len(first.Players), len(second.Players))
+	}
+}
+
+func TestHandForHand(t *testing.T) {
+	tournament := newTestTournament(6, 3, []int{40, 30, 20, 10})
+	tournament.Start()
+	first, second := tournament.Tables[0], tournament.Tables[1]
+
+	bust(t, tournament, first, first.Players[0])
+	if !tournament.HandForHand {
+		t.Fatalf("Expected hand-for-hand play on the bubble")
+	}
+
+	bust(t, tournament, first)
+	if err := tournament.StartHand(first); err == nil {
+		t.Errorf("Expected the first table to wait for the second")
+	}
+
+	bust(t, tournament, second)
+	if err := tournament.StartHand(first); err != nil {
+		t.Errorf("Unexpected error starting the next hand-for-hand hand: %v", err)
+	}
+	tournament.GameAt(first).EndHand()
+
+	// Players going out in the same hand-for-hand hand tie.
+	tournament = newTestTournament(6, 3, []int{40, 30, 20, 10})
+	tournament.Start()
+	first, second = tournament.Tables[0], tournament.Tables[1]
+	bust(t, tournament, first, first.Players[0])
+	bust(t, tournament, first, first.Players[0])
+	bust(t, tournament, second, second.Players[0])
+
+	if tournament.HandForHand {
+		t.Errorf("Expected hand-for-hand play to end after the bubble")
+	}
+	if len(tournament.Results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(tournament.Results))
+	}
+	for _, result := range tournament.Results[1:] {
+		if result.Position != 4 || result.Prize != 30 {
+			t.Errorf("Expected %s to tie for fourth for 30, got %d for %d", result.Player.Name, result.Position, result.Prize)
+		}
+	}
+}
+
+func TestHandForHandSameTable(t *testing.T) {
+	tournament := newTestTournament(6, 3, []int{40, 30, 20, 10})
+	tournament.Start()
+	first, second := tournament.Tables[0], tournament.Tables[1]
+	bust(t, tournament, first, first.Players[0])
+
+	// In one hand-for-hand hand two players go out at the second table and
+	// one at the first
+	for _, tbl := range []*table.Table{first, second} {
+		if err := tournament.StartHand(tbl); err != nil {
+			t.Fatalf("Unexpected error starting hand: %v", err)
+		}
+	}
+	short, tall, other := second.Players[0], second.Players[1], first.Players[0]
+	tournament.handStacks[short], tournament.handStacks[tall], tournament.handStacks[other] = 500, 1500, 2000
+	for _, p := range []*player.Player{short, tall, other} {
+		p.Fold()
+		p.Stack = 0
+	}
+	tournament.EndHand(first)
+	tournament.EndHand(second)
+
+	// The shorter stack at the second table ties with the player from the
+	// first for fourth and fifth, and the taller finishes third
+	expected := map[*player.Player][2]int{short: {4, 30}, other: {4, 30}, tall: {3, 120}}
+	for _, result := range tournament.Results[1:] {
+		want, ok := expected[result.Player]
+		if !ok || result.Position != want[0] || result.Prize != want[1] {
+			t.Errorf("Unexpected result for %s: position %d for %d", result.Player.Name, result.Position, result.Prize)
+		}
+		delete(expected, result.Player)
+	}
+	if len(expected) != 0 {
+		t.Errorf("Expected results for every player out, missing %d", len(expected))
+	}
+}
+
+func TestFailedMoveKeepsPlayerSeated(t *testing.T) {
+	tournament := newTestTournament(8, 4, []int{100})
+	tournament.Start()
+	first, second := tournament.Tables[0], tournament.Tables[1]
+	p := first.Players[0]
+
+	// The other table is full
+	if err := tournament.movePlayer(first, p); err == nil {
+		t.Error("Expected error moving a player to a full table")
+	}
+
+	// The other table refuses the player's stack
+	second.StandUp(second.Players[0].ID)
+	second.MinBuyIn = p.Stack + 1
+	if err := tournament.movePlayer(first, p); err == nil {
+		t.Error("Expected error moving a player the new table will not seat")
+	}
+	if first.PlayerAt(p.Seat) != p || len(first.Players) != 4 || len(second.Players) != 3 {
+		t.Errorf("Expected %s to keep their seat, got tables of %d and %d", p.Name, len(first.Players), len(second.Players))
+	}
+
+	second.MinBuyIn = 0
+	seat := p.Seat
+	if err := tournament.movePlayer(first, p); err != nil {
+		t.Fatalf("Unexpected error moving a player: %v", err)
+	}
+	if first.PlayerAt(seat) != nil || second.PlayerAt(p.Seat) != p {
+		t.Errorf("Expected %s to leave seat %d for the other table", p.Name, seat)
+	}
+}
@@ -10,15 +10,22 @@ import (
 )
 
 type GameState struct {
+	Game           string        `json:"game"`
+	NextGame       string        `json:"nextGame"`
+	HandsLeft      int           `json:"handsLeft"`
 	Players        []PlayerState `json:"players"`
 	CommunityCards []Card        `json:"communityCards"`
+	Runouts        [][]Card      `json:"runouts"`
 	Pot            int           `json:"pot"`
 }
 
 type PlayerState struct {
-	Name  string `json:"name"`
-	Stack int    `json:"stack"`
-	Hand  []Card `json:"hand"`
+	Name      string  `json:"name"`
+	Stack     int     `json:"stack"`
+	Bet       int     `json:"bet"`
+	Committed int     `json:"committed"`
+	Status    string  `json:"status"`
+	Hand      []*Card `json:"hand"` // Hidden cards are null
 }
 
 type SuitType struct {